@@ -0,0 +1,186 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	"unsafe"
+
+/*	Bit hacks for all integer widths ( see Integer ), sources :
+
+		http://aggregate.org/MAGIC/
+		H. S. Warren, "Hacker's Delight"
+
+	Values are processed as unsigned numbers of the type width, so negative signed ones are their two's complement bits
+*/
+
+
+/*	Population count : number of set bits
+
+	Sums the bits in parallel : pairs, nibbles, bytes and then all the bytes by the multiplication
+*/
+func Bit_count [ T  Integer ] ( x  T )		int	{
+
+	var value	= bit_unsigned ( x )
+
+	value	= value - ( ( value >> 1 ) & 0x5555555555555555 )
+	value	= ( value & 0x3333333333333333 ) + ( ( value >> 2 ) & 0x3333333333333333 )
+	value	= ( value + ( value >> 4 ) ) & 0x0F0F0F0F0F0F0F0F
+
+	return	int ( ( value * 0x0101010101010101 ) >> 56 )
+}
+
+/*	Number of leading zero bits, the width of the type for 0
+
+	Smears the highest set bit to the right, so the rest are the leading zeros
+*/
+func Bit_leading_zeros [ T  Integer ] ( x  T )		int	{
+
+	var value	= bit_smear ( bit_unsigned ( x ) )
+
+	return	bit_width [ T ] () - Bit_count ( value )
+}
+
+/*	Number of trailing zero bits, the width of the type for 0
+
+	( x & -x ) -1 sets the trailing zeros only
+*/
+func Bit_trailing_zeros [ T  Integer ] ( x  T )		int	{
+
+	var value	= bit_unsigned ( x )
+
+	return	Bit_count ( ( ( value & -value ) -1 ) & bit_mask [ T ] () )
+}
+
+/*	Smallest power of two >= x, 1 for x <= 0
+
+	Returns 0 if the power overflows the type, for signed types the sign bit is not a power ( see Bit_is_power_of_two )
+*/
+func Bit_next_power_of_two [ T  Integer ] ( x  T )		T	{
+
+	if	x <= 0	{	return	1	}
+
+	var value	= bit_smear ( uint64 ( x ) -1 ) +1
+
+	if	value > bit_positive_max [ T ] ()	{	return	0	}
+
+	return	T ( value )
+}
+
+//	Largest power of two <= x, 0 for x <= 0 ( there is no such power )
+func Bit_prev_power_of_two [ T  Integer ] ( x  T )		T	{
+
+	if	x <= 0	{	return	0	}
+
+	var value	= bit_smear ( uint64 ( x ) )
+
+	return	T ( value - ( value >> 1 ) )
+}
+
+//	True if x is a positive power of two : it has a single set bit
+func Bit_is_power_of_two [ T  Integer ] ( x  T )		bool	{
+
+	return	x > 0	&& x & ( x -1 ) == 0
+}
+
+/*	Reverses the order of bits in the width of the type
+
+	Swaps neighbour bits, pairs, nibbles and bytes in parallel, then the byte order of 64 bits
+*/
+func Bit_reverse [ T  Integer ] ( x  T )		T	{
+
+	var value	= uint64 ( x )
+
+	value	= ( ( value >> 1 ) & 0x5555555555555555 ) | ( ( value & 0x5555555555555555 ) << 1 )
+	value	= ( ( value >> 2 ) & 0x3333333333333333 ) | ( ( value & 0x3333333333333333 ) << 2 )
+	value	= ( ( value >> 4 ) & 0x0F0F0F0F0F0F0F0F ) | ( ( value & 0x0F0F0F0F0F0F0F0F ) << 4 )
+	value	= ( ( value >> 8 ) & 0x00FF00FF00FF00FF ) | ( ( value & 0x00FF00FF00FF00FF ) << 8 )
+	value	= ( ( value >> 16 ) & 0x0000FFFF0000FFFF ) | ( ( value & 0x0000FFFF0000FFFF ) << 16 )
+	value	= ( value >> 32 ) | ( value << 32 )
+
+	return	T ( value >> ( 64 - bit_width [ T ] () ) )
+}
+
+//	Isolates the lowest set bit : x & -x, 0 for 0
+func Bit_lowest [ T  Integer ] ( x  T )		T	{
+
+	return	x & -x
+}
+
+//	Clears the lowest set bit : x & ( x -1 )
+func Bit_clear_lowest [ T  Integer ] ( x  T )		T	{
+
+	return	x & ( x -1 )
+}
+
+/*	Sign of x : -1, 0 or 1 ( 0 or 1 for unsigned types )
+
+		( x >> ( width -1 ) ) | ( uint64 ( x | -x ) >> 63 )
+
+	The arithmetic shift of a signed x is -1 for negative values ( it is masked off for unsigned types ),
+	x | -x of the bits in the type width has the highest bit set for any x != 0
+*/
+func Bit_sign [ T  Integer ] ( x  T )		T	{
+
+	var (
+		value	= bit_unsigned ( x )
+		nonzero	= T ( ( value | -value ) >> 63 )
+	)
+	return	( ( x >> ( bit_width [ T ] () -1 ) ) & -bit_signed [ T ] () ) | nonzero
+}
+
+/*	Conditional negation by the flag arithmetic ( the bool is only converted to f ) :
+
+		( x ^ -f ) + f,	f = 0 or 1
+*/
+func Bit_negate_if [ T  Integer ] ( x  T, negate  bool )		T	{
+
+	var flag	T
+
+	if	negate	{	flag	= 1	}
+
+	return	( x ^ -flag ) + flag
+}
+
+
+func bit_width [ T  Integer ] ()		int	{
+
+	var x	T
+	return	int ( unsafe.Sizeof ( x ) ) * 8
+}
+
+//	All ones in the width of the type
+func bit_mask [ T  Integer ] ()		uint64	{
+
+	return	^uint64 ( 0 ) >> ( 64 - bit_width [ T ] () )
+}
+
+//	Bits of x as uint64 without the sign extension
+func bit_unsigned [ T  Integer ] ( x  T )		uint64	{
+
+	return	uint64 ( x ) & bit_mask [ T ] ()
+}
+
+//	Largest positive value of the type
+func bit_positive_max [ T  Integer ] ()		uint64	{
+
+	return	bit_mask [ T ] () >> uint64 ( bit_signed [ T ] () )
+}
+
+//	1 for signed types, 0 for unsigned ones ( see bit_less_mask )
+func bit_signed [ T  Integer ] ()		T	{
+
+	return	T ( uint64 ( ^T ( 0 ) >> 1 ) >> 63 )
+}
+
+//	Sets all bits below the highest set bit
+func bit_smear ( value  uint64 )		uint64	{
+
+	value	|= value >> 1
+	value	|= value >> 2
+	value	|= value >> 4
+	value	|= value >> 8
+	value	|= value >> 16
+	value	|= value >> 32
+
+	return	value
+}
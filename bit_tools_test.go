@@ -0,0 +1,144 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"math"
+	"math/bits"
+	"testing"
+)
+
+
+//	Edge values and pseudo random ones
+func bit_test_values ()		( values  [] uint64 )	{
+
+	values	= [] uint64 { 0, 1, 2, 3, 5, 0x7F, 0x80, 0xFF, 0x100, 0x7FFF, 0x8000, 0xFFFF, 1 << 31, 1 << 32 -1, 1 << 63, math.MaxUint64 }
+
+	for	state, i := uint64 ( 88172645463325252 ), 0 ; i < 1000 ; i ++	{
+
+		state	^= state << 13
+		state	^= state >> 7
+		state	^= state << 17
+		values	= append ( values, state, state >> ( i % 64 ) )
+	}
+	return
+}
+
+//	Next power of two by math/bits, 0 if it overflows the width
+func next_power ( value  uint64, width  int )		uint64	{
+
+	if	value <= 1	{	return	1	}
+
+	if	length := bits.Len64 ( value -1 ) ; length < width	{	return	1 << length	}
+
+	return	0
+}
+
+func Test_Bit_tools ( t * testing.T )	{
+
+	t.Parallel ()
+
+	for	_, value := range	bit_test_values ()	{
+
+		var (
+			v8, v16, v32, v64	= uint8 ( value ), uint16 ( value ), uint32 ( value ), value
+			i8, i32, i64	= int8 ( value ), int32 ( value ), int64 ( value )
+		)
+
+		if	Bit_count ( v8 ) != bits.OnesCount8 ( v8 )	|| Bit_count ( v16 ) != bits.OnesCount16 ( v16 )	||
+			Bit_count ( v32 ) != bits.OnesCount32 ( v32 )	|| Bit_count ( v64 ) != bits.OnesCount64 ( v64 )	||
+			Bit_count ( i8 ) != bits.OnesCount8 ( v8 )	|| Bit_count ( i64 ) != bits.OnesCount64 ( v64 )	{
+
+			t.Errorf ( "Bit_count ( %#x ) got : %d, %d, %d, %d", value, Bit_count ( v8 ), Bit_count ( v16 ), Bit_count ( v32 ), Bit_count ( v64 ) )
+		}
+
+		if	Bit_leading_zeros ( v8 ) != bits.LeadingZeros8 ( v8 )	|| Bit_leading_zeros ( v16 ) != bits.LeadingZeros16 ( v16 )	||
+			Bit_leading_zeros ( v32 ) != bits.LeadingZeros32 ( v32 )	|| Bit_leading_zeros ( v64 ) != bits.LeadingZeros64 ( v64 )	||
+			Bit_leading_zeros ( i32 ) != bits.LeadingZeros32 ( v32 )	|| Bit_leading_zeros ( uint ( value ) ) != bits.LeadingZeros ( uint ( value ) )	{
+
+			t.Errorf ( "Bit_leading_zeros ( %#x ) got : %d, %d, %d, %d", value, Bit_leading_zeros ( v8 ), Bit_leading_zeros ( v16 ), Bit_leading_zeros ( v32 ), Bit_leading_zeros ( v64 ) )
+		}
+
+		if	Bit_trailing_zeros ( v8 ) != bits.TrailingZeros8 ( v8 )	|| Bit_trailing_zeros ( v16 ) != bits.TrailingZeros16 ( v16 )	||
+			Bit_trailing_zeros ( v32 ) != bits.TrailingZeros32 ( v32 )	|| Bit_trailing_zeros ( v64 ) != bits.TrailingZeros64 ( v64 )	||
+			Bit_trailing_zeros ( i8 ) != bits.TrailingZeros8 ( v8 )	{
+
+			t.Errorf ( "Bit_trailing_zeros ( %#x ) got : %d, %d, %d, %d", value, Bit_trailing_zeros ( v8 ), Bit_trailing_zeros ( v16 ), Bit_trailing_zeros ( v32 ), Bit_trailing_zeros ( v64 ) )
+		}
+
+		if	Bit_reverse ( v8 ) != bits.Reverse8 ( v8 )	|| Bit_reverse ( v16 ) != bits.Reverse16 ( v16 )	||
+			Bit_reverse ( v32 ) != bits.Reverse32 ( v32 )	|| Bit_reverse ( v64 ) != bits.Reverse64 ( v64 )	||
+			Bit_reverse ( i32 ) != int32 ( bits.Reverse32 ( v32 ) )	{
+
+			t.Errorf ( "Bit_reverse ( %#x ) got : %#x, %#x, %#x, %#x", value, Bit_reverse ( v8 ), Bit_reverse ( v16 ), Bit_reverse ( v32 ), Bit_reverse ( v64 ) )
+		}
+
+		if	Bit_next_power_of_two ( v8 ) != uint8 ( next_power ( uint64 ( v8 ), 8 ) )	|| Bit_next_power_of_two ( v16 ) != uint16 ( next_power ( uint64 ( v16 ), 16 ) )	||
+			Bit_next_power_of_two ( v32 ) != uint32 ( next_power ( uint64 ( v32 ), 32 ) )	|| Bit_next_power_of_two ( v64 ) != next_power ( v64, 64 )	{
+
+			t.Errorf ( "Bit_next_power_of_two ( %#x ) got : %#x, %#x, %#x, %#x", value, Bit_next_power_of_two ( v8 ), Bit_next_power_of_two ( v16 ), Bit_next_power_of_two ( v32 ), Bit_next_power_of_two ( v64 ) )
+		}
+
+		var previous	uint64
+
+		if	v64 != 0	{	previous	= 1 << ( bits.Len64 ( v64 ) -1 )	}
+
+		if	Bit_prev_power_of_two ( v64 ) != previous	|| Bit_prev_power_of_two ( v32 ) != uint32 ( uint64 ( 1 ) << bits.Len32 ( v32 ) >> 1 )	{
+			t.Errorf ( "Bit_prev_power_of_two ( %#x ) got : %#x, %#x", value, Bit_prev_power_of_two ( v64 ), Bit_prev_power_of_two ( v32 ) )
+		}
+
+		if	Bit_is_power_of_two ( v64 ) != ( bits.OnesCount64 ( v64 ) == 1 )	|| Bit_is_power_of_two ( i64 ) != ( i64 > 0 && bits.OnesCount64 ( v64 ) == 1 )	{
+			t.Errorf ( "Bit_is_power_of_two ( %#x ) got : %v, %v", value, Bit_is_power_of_two ( v64 ), Bit_is_power_of_two ( i64 ) )
+		}
+
+		if	v64 != 0	&& ( Bit_lowest ( v64 ) != 1 << bits.TrailingZeros64 ( v64 )	|| Bit_clear_lowest ( v64 ) != v64 ^ Bit_lowest ( v64 )	||
+			Bit_lowest ( i8 ) != int8 ( 1 << bits.TrailingZeros8 ( v8 ) ) && v8 != 0 )	{
+
+			t.Errorf ( "Bit_lowest / Bit_clear_lowest ( %#x ) got : %#x, %#x", value, Bit_lowest ( v64 ), Bit_clear_lowest ( v64 ) )
+		}
+
+		var sign	int64
+
+		if	i64 > 0	{	sign	= 1	}
+		if	i64 < 0	{	sign	= -1	}
+
+		if	Bit_sign ( i64 ) != sign	|| Bit_sign ( i8 ) != int8 ( max ( -1, min ( 1, int64 ( i8 ) ) ) )	|| Bit_sign ( v32 ) != uint32 ( min ( 1, v32 ) )	{
+			t.Errorf ( "Bit_sign ( %#x ) got : %d, %d, %d", value, Bit_sign ( i64 ), Bit_sign ( i8 ), Bit_sign ( v32 ) )
+		}
+
+		if	Bit_negate_if ( i64, true ) != -i64	|| Bit_negate_if ( i64, false ) != i64	|| Bit_negate_if ( v16, true ) != -v16	{
+			t.Errorf ( "Bit_negate_if ( %#x ) got : %d, %d, %d", value, Bit_negate_if ( i64, true ), Bit_negate_if ( i64, false ), Bit_negate_if ( v16, true ) )
+		}
+	}
+
+	if	Bit_lowest ( 0 ) != 0	|| Bit_prev_power_of_two ( uint8 ( 0 ) ) != 0	|| Bit_next_power_of_two ( int8 ( 0 ) ) != 1	|| Bit_next_power_of_two ( int8 ( 100 ) ) != 0	{
+		t.Error ( "Bit tools of 0 and signed overflow expected = 0, 0, 1, 0" )
+	}
+
+	if	Bit_next_power_of_two ( uint8 ( 200 ) ) != 0	|| Bit_next_power_of_two ( int64 ( math.MaxInt64 ) ) != 0	|| Bit_next_power_of_two ( int64 ( 1 << 62 ) ) != 1 << 62	{
+		t.Error ( "Bit_next_power_of_two overflow of the positive range expected = 0" )
+	}
+
+//	Signed powers are positive : next is 1 for x <= 0, prev is 0 for x <= 0
+	for	x := math.MinInt8 ; x <= math.MaxInt8 ; x ++	{
+
+		var (
+			next	= Bit_next_power_of_two ( int8 ( x ) )
+			prev	= Bit_prev_power_of_two ( int8 ( x ) )
+
+			expected_next, expected_prev	= 1, 0
+		)
+
+		for	; expected_next < x ; expected_next *= 2	{}
+		for	power := 1 ; power <= x ; power *= 2	{	expected_prev	= power	}
+
+		if	expected_next > math.MaxInt8	{	expected_next	= 0	}
+
+		if	int ( next ) != expected_next	|| int ( prev ) != expected_prev	||
+			next != 0 && ! Bit_is_power_of_two ( next )	|| prev != 0 && ! Bit_is_power_of_two ( prev )	{
+
+			t.Errorf ( "Bit_next / prev_power_of_two ( int8 ( %d ) ) expected = %d, %d, got : %d, %d", x, expected_next, expected_prev, next, prev )
+		}
+	}
+}
@@ -0,0 +1,261 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"cmp"
+	"iter"
+)
+
+/*	Iterators reuse one slice for all the values ( nothing is allocated per value ),
+	so a value must not be modified and should be copied if it is kept after the next step
+*/
+
+
+/*	k-subsets of { 0, 1, ... n -1 } as increasing indexes in lexicographic order, C ( n, k ) values, see Next_combination
+*/
+func Combinations ( n, k  int )		iter.Seq [ [] int ]	{
+
+	return	func ( yield  func ( [] int ) bool )	{
+
+		if	k < 0	|| k > n	{	return	}
+
+		var combination	= make ( [] int, k )
+
+		for	i := range	combination	{	combination [ i ]	= i	}
+
+		for	ok := true ; ok ; ok	= Next_combination ( combination, n )	{
+			if	! yield ( combination )	{	return	}
+		}
+	}
+}
+
+/*	Advances the increasing indexes to the next k-subset of { 0, 1, ... n -1 } in lexicographic order
+
+	Returns false ( the combination is not changed ) if it is the last one
+*/
+func Next_combination ( combination  [] int, n  int )		bool	{
+
+	var k	= len ( combination )
+
+	for	i := k -1 ; i >= 0 ; i --	{
+
+		if	combination [ i ] < n - k + i	{
+
+			combination [ i ] ++
+
+			for	j := i +1 ; j < k ; j ++	{
+				combination [ j ]	= combination [ j -1 ] +1
+			}
+			return	true
+		}
+	}
+	return	false
+}
+
+/*	k-subsets of { 0, 1, ... n -1 } in revolving door ( Gray ) order : the next subset differs by one removed and one added element
+
+	The order is defined recursively ( D. Knuth, TAOCP 7.2.1.3 ) :
+
+		Γ ( n, k ) = Γ ( n -1, k ),  reversed Γ ( n -1, k -1 ) with n -1 added
+*/
+func Combinations_revolving_door ( n, k  int )		iter.Seq [ [] int ]	{
+
+	return	func ( yield  func ( [] int ) bool )	{
+
+		if	k < 0	|| k > n	{	return	}
+
+		var combination	= make ( [] int, k )
+
+		revolving_door ( combination, n, k, true, yield )
+	}
+}
+
+//	Fills combination [ : k ] with elements < n, other positions are set by the callers
+func revolving_door ( combination  [] int, n, k  int, forward  bool, yield  func ( [] int ) bool )		bool	{
+
+	if	k == 0	{	return	yield ( combination )	}
+
+	if	k == n	{
+
+		for	i := 0 ; i < k ; i ++	{	combination [ i ]	= i	}
+
+		return	yield ( combination )
+	}
+
+	if	forward	{
+
+		if	! revolving_door ( combination, n -1, k, true, yield )	{	return	false	}
+
+		combination [ k -1 ]	= n -1
+		return	revolving_door ( combination, n -1, k -1, false, yield )
+	}
+
+	combination [ k -1 ]	= n -1
+
+	if	! revolving_door ( combination, n -1, k -1, true, yield )	{	return	false	}
+
+	return	revolving_door ( combination, n -1, k, false, yield )
+}
+
+/*	Lexicographic rank of the k-subset ( increasing indexes ) of { 0, 1, ... n -1 }, see Combinations
+
+		rank = C ( n, k ) -1 - Σ C ( n -1 - c_i, k - i ),	0 <= i < k
+
+	Return
+
+		err	: indexes are out of range or not increasing ( see Arg_range_error ), C ( n, k ) doesn't fit uint64 ( see Overflow_error )
+*/
+func Combination_rank ( combination  [] int, n  int )		( rank  uint64, err  error )	{
+
+	var k	= len ( combination )
+
+	for	i, element := range	combination	{
+
+		if	element < 0	|| element >= n	|| i > 0 && element <= combination [ i -1 ]	{	return	0, Arg_range_error ()	}
+	}
+
+	if	rank, err = binomial_or_zero ( n, k ) ; err != nil	{	return	0, err	}
+
+	rank	--
+
+	for	i, element := range	combination	{
+
+//		C ( n -1 - c_i, k - i ) <= C ( n, k ), so it fits
+		var count, _	= binomial_or_zero ( n -1 - element, k - i )
+
+		rank	-= count
+	}
+	return
+}
+
+/*	k-subset of { 0, 1, ... n -1 } with the lexicographic rank, see Combination_rank
+
+	Return
+
+		err	: k is out of [ 0, n ] or rank >= C ( n, k ) ( see Arg_range_error ), C ( n, k ) doesn't fit uint64 ( see Overflow_error )
+*/
+func Combination_unrank ( rank  uint64, n, k  int )		( combination  [] int, err  error )	{
+
+	if	k < 0	|| k > n	{	return	nil, Arg_range_error ()	}
+
+	var total	uint64
+
+	if	total, err = binomial_or_zero ( n, k ) ; err != nil	{	return	nil, err	}
+
+	if	rank >= total	{	return	nil, Arg_range_error ()	}
+
+	var (
+		rest	= total -1 - rank
+		value	= n -1
+	)
+	combination	= make ( [] int, k )
+
+	for	i := range	combination	{
+
+//		Largest value with C ( value, k - i ) <= rest, value <= n -1 - i so the binomials fit
+		var count, _	= binomial_or_zero ( value, k - i )
+
+		for	count > rest	{
+			value --
+			count, _	= binomial_or_zero ( value, k - i )
+		}
+
+		combination [ i ]	= n -1 - value
+		rest	-= count
+		value --
+	}
+	return
+}
+
+//	Binomial_coefficient_checked for int arguments, 0 if k is out of [ 0, n ]
+func binomial_or_zero ( n, k  int )		( uint64, error )	{
+
+	if	k < 0	|| k > n	{	return	0, nil	}
+
+	return	Binomial_coefficient_checked ( uint64 ( n ), uint64 ( k ) )
+}
+
+
+/*	Permutations of { 0, 1, ... n -1 } by Heap's algorithm : the next permutation differs by one swap, n ! values
+*/
+func Permutations_heap ( n  int )		iter.Seq [ [] int ]	{
+
+	return	func ( yield  func ( [] int ) bool )	{
+
+		if	n < 0	{	return	}
+
+		var (
+			permutation	= make ( [] int, n )
+//			Stack state of the recursive algorithm
+			counters	= make ( [] int, n )
+		)
+
+		for	i := range	permutation	{	permutation [ i ]	= i	}
+
+		if	! yield ( permutation )	{	return	}
+
+		for	i := 1 ; i < n ;	{
+
+			if	counters [ i ] >= i	{
+				counters [ i ]	= 0
+				i ++
+				continue
+			}
+
+			if	i % 2 == 0	{
+				permutation [ 0 ], permutation [ i ]	= permutation [ i ], permutation [ 0 ]
+			} else	{
+				permutation [ counters [ i ] ], permutation [ i ]	= permutation [ i ], permutation [ counters [ i ] ]
+			}
+
+			if	! yield ( permutation )	{	return	}
+
+			counters [ i ] ++
+			i	= 1
+		}
+	}
+}
+
+//	Permutations of { 0, 1, ... n -1 } in lexicographic order, see Next_permutation
+func Permutations ( n  int )		iter.Seq [ [] int ]	{
+
+	return	func ( yield  func ( [] int ) bool )	{
+
+		if	n < 0	{	return	}
+
+		var permutation	= make ( [] int, n )
+
+		for	i := range	permutation	{	permutation [ i ]	= i	}
+
+		for	ok := true ; ok ; ok	= Next_permutation ( permutation )	{
+			if	! yield ( permutation )	{	return	}
+		}
+	}
+}
+
+/*	Rearranges the values into the next lexicographically greater permutation ( repeated values are permuted once )
+
+	Returns false and sorts the values ascending if they are the last permutation
+*/
+func Next_permutation [ T  cmp.Ordered ] ( values  [] T )		bool	{
+
+	var i	= len ( values ) -2
+
+	for	; i >= 0 && values [ i ] >= values [ i +1 ] ; i --	{}
+
+	if	i >= 0	{
+
+		var j	= len ( values ) -1
+
+		for	; values [ j ] <= values [ i ] ; j --	{}
+
+		values [ i ], values [ j ]	= values [ j ], values [ i ]
+	}
+
+	for	left, right := i +1, len ( values ) -1 ; left < right ; left, right = left +1, right -1	{
+		values [ left ], values [ right ]	= values [ right ], values [ left ]
+	}
+	return	i >= 0
+}
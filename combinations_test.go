@@ -0,0 +1,208 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"fmt"
+	"slices"
+	"testing"
+)
+
+
+func Test_Combinations ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var lexicographic	[] string
+
+	for	combination := range	Combinations ( 5, 3 )	{
+		lexicographic	= append ( lexicographic, fmt.Sprint ( combination ) )
+	}
+
+	if	result := fmt.Sprint ( lexicographic ) ; result != "[[0 1 2] [0 1 3] [0 1 4] [0 2 3] [0 2 4] [0 3 4] [1 2 3] [1 2 4] [1 3 4] [2 3 4]]"	{
+		t.Errorf ( "Combinations ( 5, 3 ) got : %s", result )
+	}
+
+	for	n := 0 ; n <= 9 ; n ++	{
+		for	k := 0 ; k <= n ; k ++	{
+
+			var (
+				count		uint64
+				previous	[] int
+				seen		= make ( map [ string ] bool )
+			)
+
+			for	combination := range	Combinations ( n, k )	{
+
+				if	rank, err := Combination_rank ( combination, n ) ; err != nil	|| rank != count	{
+					t.Errorf ( "Combination_rank ( %v, %d ) expected = %d, got : %d, %v", combination, n, count, rank, err )
+				}
+
+				if	result, err := Combination_unrank ( count, n, k ) ; err != nil	|| ! slices.Equal ( result, combination )	{
+					t.Errorf ( "Combination_unrank ( %d, %d, %d ) expected = %v, got : %v, %v", count, n, k, combination, result, err )
+				}
+				count ++
+			}
+
+			for	combination := range	Combinations_revolving_door ( n, k )	{
+
+				if	! slices.IsSorted ( combination )	|| seen [ fmt.Sprint ( combination ) ]	{
+					t.Errorf ( "Revolving door ( %d, %d ) value %v is not sorted or repeated", n, k, combination )
+				}
+				seen [ fmt.Sprint ( combination ) ]	= true
+
+				if	previous != nil	&& len ( difference ( previous, combination ) ) != 1	{
+					t.Errorf ( "Revolving door ( %d, %d ) %v -> %v changes more than one element", n, k, previous, combination )
+				}
+				previous	= append ( previous [ : 0 ], combination... )
+			}
+
+			if	expected := Binomial_coefficient ( uint ( n ), uint ( k ) ) ; uint ( count ) != expected	|| uint ( len ( seen ) ) != expected	{
+				t.Errorf ( "Combinations ( %d, %d ) expected = %d values, got : %d, %d", n, k, expected, count, len ( seen ) )
+			}
+		}
+	}
+
+	if	_, err := Combination_unrank ( 10, 5, 3 ) ; err == nil	{
+		t.Error ( "Rank 10 is out of C ( 5, 3 ) but there is no error" )
+	}
+
+	for	_, combination := range	[][] int { { 5, 2 }, { 2, 1 }, { 1, 1 }, { -1, 2 }, { 0, 4 }, { 0, 1, 2, 3, 4 } }	{
+
+		if	rank, err := Combination_rank ( combination, 4 ) ; err == nil	{
+			t.Errorf ( "Combination_rank ( %v, 4 ) expected an error, got : %d", combination, rank )
+		}
+	}
+
+//	C ( 66, 33 ) = 7219428434016265740 fits uint64, Binomial_coefficient overflows on it
+	var last	= make ( [] int, 33 )
+
+	for	i := range	last	{	last [ i ]	= 33 + i	}
+
+	if	rank, err := Combination_rank ( last, 66 ) ; err != nil	|| rank != 7219428434016265739	{
+		t.Errorf ( "Combination_rank ( [ 33 ... 65 ], 66 ) expected = 7219428434016265739, got : %d, %v", rank, err )
+	}
+
+	if	result, err := Combination_unrank ( 7219428434016265739, 66, 33 ) ; err != nil	|| ! slices.Equal ( result, last )	{
+		t.Errorf ( "Combination_unrank ( C ( 66, 33 ) -1, 66, 33 ) expected = %v, got : %v, %v", last, result, err )
+	}
+
+	if	result, err := Combination_unrank ( 0, 66, 33 ) ; err != nil	|| result [ 0 ] != 0	|| result [ 32 ] != 32	{
+		t.Errorf ( "Combination_unrank ( 0, 66, 33 ) expected = [ 0 ... 32 ], got : %v, %v", result, err )
+	}
+
+	if	_, err := Combination_unrank ( 7219428434016265740, 66, 33 ) ; err == nil	{
+		t.Error ( "Rank C ( 66, 33 ) is out of range but there is no error" )
+	}
+
+	if	_, err := Combination_rank ( [] int { 0 }, 68 ) ; err != nil	{
+		t.Errorf ( "Combination_rank ( [ 0 ], 68 ) unexpected error : %v", err )
+	}
+
+	if	_, err := Combination_rank ( last [ : 0 : 0 ], 70 ) ; err != nil	{
+		t.Errorf ( "Combination_rank ( [], 70 ) unexpected error : %v", err )
+	}
+
+	var middle	= make ( [] int, 35 )
+
+	for	i := range	middle	{	middle [ i ]	= i	}
+
+	if	_, err := Combination_rank ( middle, 70 ) ; err == nil	{
+		t.Error ( "C ( 70, 35 ) overflows uint64 but Combination_rank has no error" )
+	}
+
+	if	_, err := Combination_unrank ( 0, 70, 35 ) ; err == nil	{
+		t.Error ( "C ( 70, 35 ) overflows uint64 but Combination_unrank has no error" )
+	}
+
+//	Early break
+	for	combination := range	Combinations_revolving_door ( 20, 10 )	{
+		if	len ( combination ) == 10	{	break	}
+	}
+
+	for	range	Combinations ( 3, 4 )	{
+		t.Error ( "Combinations ( 3, 4 ) expected to be empty" )
+	}
+}
+
+//	Elements of a missing from b
+func difference ( a, b  [] int )		( result  [] int )	{
+
+	for	_, value := range	a	{
+		if	! slices.Contains ( b, value )	{	result	= append ( result, value )	}
+	}
+	return
+}
+
+func Test_Permutations ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var lexicographic	[] string
+
+	for	permutation := range	Permutations ( 3 )	{
+		lexicographic	= append ( lexicographic, fmt.Sprint ( permutation ) )
+	}
+
+	if	result := fmt.Sprint ( lexicographic ) ; result != "[[0 1 2] [0 2 1] [1 0 2] [1 2 0] [2 0 1] [2 1 0]]"	{
+		t.Errorf ( "Permutations ( 3 ) got : %s", result )
+	}
+
+	for	n := 0 ; n <= 7 ; n ++	{
+
+		var (
+			heap		= make ( map [ string ] bool )
+			previous	[] int
+			factorial	= 1
+		)
+
+		for	i := 2 ; i <= n ; i ++	{	factorial	*= i	}
+
+		for	permutation := range	Permutations_heap ( n )	{
+
+			if	previous != nil	{
+
+				var changed	int
+
+				for	i := range	permutation	{
+					if	permutation [ i ] != previous [ i ]	{	changed ++	}
+				}
+
+				if	changed != 2	{
+					t.Errorf ( "Heap's permutations %v -> %v is not a swap", previous, permutation )
+				}
+			}
+			heap [ fmt.Sprint ( permutation ) ]	= true
+			previous	= append ( previous [ : 0 ], permutation... )
+		}
+
+		var count	int
+
+		for	range	Permutations ( n )	{	count ++	}
+
+		if	len ( heap ) != factorial	|| count != factorial	{
+			t.Errorf ( "Permutations of %d expected = %d values, got : %d, %d", n, factorial, len ( heap ), count )
+		}
+	}
+
+	var values	= [] string { "a", "b", "b" }
+
+	for	_, expected := range	[] string { "[b a b]", "[b b a]", "[a b b]" }	{
+
+		var ok	= Next_permutation ( values )
+
+		if	fmt.Sprint ( values ) != expected	|| ok != ( expected != "[a b b]" )	{
+			t.Errorf ( "Next_permutation expected = %s, got : %v, %v", expected, values, ok )
+		}
+	}
+}
+
+func Benchmark_Combinations ( b * testing.B )	{
+
+	b.ReportAllocs ()
+
+	for	i := 0 ; i < b.N ; i ++	{
+		for	range	Combinations ( 20, 10 )	{}
+	}
+}
@@ -0,0 +1,294 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"math/big"
+	"math/bits"
+)
+
+/*	Combinatorial numbers in pairs, as Binomial_coefficient_checked and Binomial_big :
+
+	checked uint64 versions return Overflow_error if the result doesn't fit uint64 ( the detection is exact ),
+	big.Int versions are exact for any arguments
+*/
+
+
+/*	Multinomial coefficient ( k1 + k2 + ... + km ) ! / ( k1 ! * k2 ! * ... * km ! )
+
+	Product of binomials :	C ( k1, k1 ) * C ( k1 + k2, k2 ) * ... * C ( k1 + ... + km, km ),	see Trinomial_coefficient
+*/
+func Multinomial_coefficient ( counts  ... uint64 )		( result  uint64, err  error )	{
+
+	var total	uint64
+
+	result	= 1
+
+	for	_, count := range	counts	{
+
+		var (
+			binomial	uint64
+			ok			bool
+		)
+
+		if	total, ok	= add_checked ( total, count ) ; ! ok	{	return	0, Overflow_error ()	}
+
+		if	binomial, err	= Binomial_coefficient_checked ( total, count ) ; err != nil	{	return	0, err	}
+
+		if	result, ok	= mul_checked ( result, binomial ) ; ! ok	{	return	0, Overflow_error ()	}
+	}
+	return
+}
+
+//	See Multinomial_coefficient
+func Multinomial_big ( counts  ... uint64 )		* big.Int	{
+
+	var (
+		result	= big.NewInt ( 1 )
+		binomial	big.Int
+		total	uint64
+	)
+
+	for	_, count := range	counts	{
+
+		total	+= count
+		result.Mul ( result, Binomial_big_to ( & binomial, total, count ) )
+	}
+	return	result
+}
+
+/*	Catalan number C_n = C ( 2n, n ) / ( n +1 )
+
+	Uses the recurrence C_i+1 = C_i * 2 ( 2i +1 ) / ( i +2 ) on 128 bits, the sequence increases so the overflow is exact
+*/
+func Catalan_number ( n  uint64 )		( result  uint64, err  error )	{
+
+	result	= 1
+
+	for	i := uint64 ( 0 ) ; i < n ; i ++	{
+
+		var high, low	= bits.Mul64 ( result, 2 * ( 2 * i +1 ) )
+
+		if	high >= i +2	{	return	0, Overflow_error ()	}
+
+		result, _	= bits.Div64 ( high, low, i +2 )
+	}
+	return
+}
+
+//	See Catalan_number
+func Catalan_big ( n  uint64 )		( result  * big.Int )	{
+
+	result	= Binomial_big ( 2 * n, n )
+
+	return	result.Quo ( result, new ( big.Int ).SetUint64 ( n +1 ) )
+}
+
+/*	Unsigned Stirling number of the first kind [ n, k ] : permutations of n elements with k cycles
+
+		[ n, k ] = ( n -1 ) * [ n -1, k ]  +  [ n -1, k -1 ]
+
+	Only the band of the table leading to [ n, k ] is computed, its numbers don't exceed [ n, k ]
+*/
+func Stirling_first ( n, k  uint64 )		( uint64, error )	{
+
+	return	stirling_checked ( n, k, func ( i, _  uint64 )	uint64	{	return	i -1	} )
+}
+
+//	See Stirling_first
+func Stirling_first_big ( n, k  uint64 )		* big.Int	{
+
+	return	stirling_big ( n, k, func ( i, _  uint64 )	uint64	{	return	i -1	} )
+}
+
+/*	Stirling number of the second kind { n, k } : partitions of n elements into k non empty subsets
+
+		{ n, k } = k * { n -1, k }  +  { n -1, k -1 }
+*/
+func Stirling_second ( n, k  uint64 )		( uint64, error )	{
+
+	return	stirling_checked ( n, k, func ( _, j  uint64 )	uint64	{	return	j	} )
+}
+
+//	See Stirling_second
+func Stirling_second_big ( n, k  uint64 )		* big.Int	{
+
+	return	stirling_big ( n, k, func ( _, j  uint64 )	uint64	{	return	j	} )
+}
+
+/*	Bell number B_n : partitions of n elements, B_n = Σ { n, k }
+
+	Computed by Bell triangle, row i lies between B_i and B_i+1
+*/
+func Bell_number ( n  uint64 )		( uint64, error )	{
+
+	var row	= [] uint64 { 1 }
+
+	for	i := uint64 ( 1 ) ; i < n ; i ++	{
+
+		var (
+			next	= make ( [] uint64, i +1 )
+			ok		bool
+		)
+		next [ 0 ]	= row [ i -1 ]
+
+		for	j := range	row	{
+
+			if	next [ j +1 ], ok	= add_checked ( next [ j ], row [ j ] ) ; ! ok	{
+				return	0, Overflow_error ()
+			}
+		}
+		row	= next
+	}
+	return	row [ len ( row ) -1 ], nil
+}
+
+//	See Bell_number
+func Bell_big ( n  uint64 )		* big.Int	{
+
+	var row	= [] * big.Int { big.NewInt ( 1 ) }
+
+	for	i := uint64 ( 1 ) ; i < n ; i ++	{
+
+		var next	= make ( [] * big.Int, i +1 )
+
+		next [ 0 ]	= row [ i -1 ]
+
+		for	j := range	row	{
+			next [ j +1 ]	= new ( big.Int ).Add ( next [ j ], row [ j ] )
+		}
+		row	= next
+	}
+	return	new ( big.Int ).Set ( row [ len ( row ) -1 ] )
+}
+
+/*	Number of integer partitions p ( n ) by Euler's pentagonal number theorem
+
+		p ( n ) = Σ ( -1 )^( k +1 ) * [ p ( n - k ( 3k -1 ) / 2 )  +  p ( n - k ( 3k +1 ) / 2 ) ],	k >= 1
+
+	p ( n ) increases, so it fits uint64 for n <= partition_uint64_max and the sums are exact modulo 2^64
+*/
+func Partition_count ( n  uint64 )		( uint64, error )	{
+
+	if	n > partition_uint64_max	{	return	0, Overflow_error ()	}
+
+	var partitions	= make ( [] uint64, n +1 )
+
+	partitions [ 0 ]	= 1
+
+	for	i := uint64 ( 1 ) ; i <= n ; i ++	{
+		partitions [ i ]	= pentagonal_sum ( i, 0, func ( j  uint64 )	uint64	{	return	partitions [ j ]	},
+			func ( a, b  uint64 )	uint64	{	return	a + b	}, func ( a, b  uint64 )	uint64	{	return	a - b	} )
+	}
+	return	partitions [ n ], nil
+}
+
+//	See Partition_count
+func Partition_big ( n  uint64 )		* big.Int	{
+
+	var partitions	= make ( [] * big.Int, n +1 )
+
+	partitions [ 0 ]	= big.NewInt ( 1 )
+
+	for	i := uint64 ( 1 ) ; i <= n ; i ++	{
+		partitions [ i ]	= pentagonal_sum ( i, new ( big.Int ), func ( j  uint64 )	* big.Int	{	return	partitions [ j ]	},
+			func ( a, b  * big.Int )	* big.Int	{	return	new ( big.Int ).Add ( a, b )	},
+			func ( a, b  * big.Int )	* big.Int	{	return	new ( big.Int ).Sub ( a, b )	} )
+	}
+	return	partitions [ n ]
+}
+
+//	p ( 416 ) < 2^64 <= p ( 417 )
+const	partition_uint64_max	= 416
+
+func pentagonal_sum [ T  any ] ( n  uint64, zero  T, partition  func ( uint64 ) T, add, sub  func ( a, b  T ) T )		( result  T )	{
+
+	result	= zero
+
+	for	k := uint64 ( 1 ) ; k * ( 3 * k -1 ) / 2 <= n ; k ++	{
+
+		var combine	= add
+
+		if	k % 2 == 0	{	combine	= sub	}
+
+		result	= combine ( result, partition ( n - k * ( 3 * k -1 ) / 2 ) )
+
+		if	pentagonal := k * ( 3 * k +1 ) / 2 ; pentagonal <= n	{
+			result	= combine ( result, partition ( n - pentagonal ) )
+		}
+	}
+	return
+}
+
+
+//	Band of Stirling table : row i keeps j in [ max ( 1, k - ( n - i ) ), min ( i, k ) ]
+func stirling_checked ( n, k  uint64, factor  func ( i, j  uint64 ) uint64 )		( uint64, error )	{
+
+	if	k > n	|| k == 0 && n > 0	{	return	0, nil	}
+	if	n == 0	{	return	1, nil	}
+
+	var row	= make ( [] uint64, k +1 )
+
+	row [ 0 ]	= 1
+
+	for	i := uint64 ( 1 ) ; i <= n ; i ++	{
+
+		var low	= uint64 ( 1 )
+
+		if	k + i > n +1	{	low	= k + i - n	}
+
+		for	j := min ( i, k ) ; j >= low ; j --	{
+
+			var (
+				product, ok_product	= mul_checked ( factor ( i, j ), row [ j ] )
+				sum, ok_sum			= add_checked ( product, row [ j -1 ] )
+			)
+
+			if	! ok_product	|| ! ok_sum	{	return	0, Overflow_error ()	}
+
+			row [ j ]	= sum
+		}
+		row [ 0 ]	= 0
+	}
+	return	row [ k ], nil
+}
+
+func stirling_big ( n, k  uint64, factor  func ( i, j  uint64 ) uint64 )		* big.Int	{
+
+	if	k > n	|| k == 0 && n > 0	{	return	new ( big.Int )	}
+
+	var (
+		row		= make ( [] * big.Int, k +1 )
+		product	big.Int
+	)
+
+	for	j := range	row	{	row [ j ]	= new ( big.Int )	}
+
+	row [ 0 ].SetInt64 ( 1 )
+
+	for	i := uint64 ( 1 ) ; i <= n ; i ++	{
+
+		for	j := min ( i, k ) ; j >= 1 ; j --	{
+
+			product.SetUint64 ( factor ( i, j ) )
+			row [ j ].Add ( product.Mul ( & product, row [ j ] ), row [ j -1 ] )
+		}
+		row [ 0 ].SetInt64 ( 0 )
+	}
+	return	row [ k ]
+}
+
+func add_checked ( a, b  uint64 )		( uint64, bool )	{
+
+	var sum, carry	= bits.Add64 ( a, b, 0 )
+
+	return	sum, carry == 0
+}
+
+func mul_checked ( a, b  uint64 )		( uint64, bool )	{
+
+	var high, low	= bits.Mul64 ( a, b )
+
+	return	low, high == 0
+}
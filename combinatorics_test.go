@@ -0,0 +1,117 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"fmt"
+	"math/big"
+	"testing"
+)
+
+
+//	Checked result must equal the big one while it fits uint64 and be an overflow error otherwise
+func check_against_big ( t * testing.T, name  string, result  uint64, err  error, expected  * big.Int )	{
+
+	if	expected.IsUint64 ()	{
+
+		if	err != nil	|| result != expected.Uint64 ()	{
+			t.Errorf ( "%s expected = %s, got : %d, %v", name, expected.String (), result, err )
+		}
+		return
+	}
+
+	if	_, ok := err.( overflow_error ) ; ! ok	{
+		t.Errorf ( "%s = %s overflows uint64, expected an overflow error, got : %d, %v", name, expected.String (), result, err )
+	}
+}
+
+func Test_Combinatorial_numbers ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [] struct {
+		name	string
+		value	* big.Int
+		expected	string
+	}{
+		{ "Multinomial ( 2, 3, 4 )", Multinomial_big ( 2, 3, 4 ), "1260" },
+		{ "Multinomial ()", Multinomial_big (), "1" },
+		{ "Catalan ( 10 )", Catalan_big ( 10 ), "16796" },
+		{ "Catalan ( 0 )", Catalan_big ( 0 ), "1" },
+		{ "Stirling_first ( 10, 3 )", Stirling_first_big ( 10, 3 ), "1172700" },
+		{ "Stirling_first ( 0, 0 )", Stirling_first_big ( 0, 0 ), "1" },
+		{ "Stirling_first ( 5, 0 )", Stirling_first_big ( 5, 0 ), "0" },
+		{ "Stirling_second ( 10, 3 )", Stirling_second_big ( 10, 3 ), "9330" },
+		{ "Stirling_second ( 3, 5 )", Stirling_second_big ( 3, 5 ), "0" },
+		{ "Bell ( 0 )", Bell_big ( 0 ), "1" },
+		{ "Bell ( 8 )", Bell_big ( 8 ), "4140" },
+		{ "Partition ( 0 )", Partition_big ( 0 ), "1" },
+		{ "Partition ( 100 )", Partition_big ( 100 ), "190569292" },
+		{ "Partition ( 200 )", Partition_big ( 200 ), "3972999029388" },
+		{ "Partition ( 417 )", Partition_big ( 417 ), "18987964267331664557" },
+	}
+
+	for	_, c := range	cases	{
+
+		if	c.value.String () != c.expected	{
+			t.Errorf ( "%s expected = %s, got : %s", c.name, c.expected, c.value.String () )
+		}
+	}
+
+	var bell	= [] uint64 { 1, 1, 2, 5, 15, 52, 203, 877, 4140, 21147, 115975 }
+
+	for	n, expected := range	bell	{
+
+		if	result, err := Bell_number ( uint64 ( n ) ) ; err != nil	|| result != expected	{
+			t.Errorf ( "Bell ( %d ) expected = %d, got : %d, %v", n, expected, result, err )
+		}
+	}
+
+//	Sum of Stirling numbers of the second kind is a Bell number, of the first kind is n !
+	for	n := uint64 ( 0 ) ; n <= 30 ; n ++	{
+
+		var first, second	= new ( big.Int ), new ( big.Int )
+
+		for	k := uint64 ( 0 ) ; k <= n ; k ++	{
+			first.Add ( first, Stirling_first_big ( n, k ) )
+			second.Add ( second, Stirling_second_big ( n, k ) )
+		}
+
+		if	first.Cmp ( new ( big.Int ).MulRange ( 1, int64 ( n ) ) ) != 0	|| second.Cmp ( Bell_big ( n ) ) != 0	{
+			t.Errorf ( "Stirling sums of %d expected = %d !, Bell ( %d ), got : %s, %s", n, n, n, first.String (), second.String () )
+		}
+	}
+}
+
+func Test_Combinatorial_numbers_checked ( t * testing.T )	{
+
+	t.Parallel ()
+
+	for	n := uint64 ( 0 ) ; n <= 40 ; n ++	{
+
+		var result, err	= Catalan_number ( n )
+		check_against_big ( t, fmt.Sprintf ( "Catalan ( %d )", n ), result, err, Catalan_big ( n ) )
+
+		result, err	= Bell_number ( n )
+		check_against_big ( t, fmt.Sprintf ( "Bell ( %d )", n ), result, err, Bell_big ( n ) )
+
+		for	k := uint64 ( 0 ) ; k <= n +1 ; k ++	{
+
+			result, err	= Stirling_first ( n, k )
+			check_against_big ( t, fmt.Sprintf ( "Stirling_first ( %d, %d )", n, k ), result, err, Stirling_first_big ( n, k ) )
+
+			result, err	= Stirling_second ( n, k )
+			check_against_big ( t, fmt.Sprintf ( "Stirling_second ( %d, %d )", n, k ), result, err, Stirling_second_big ( n, k ) )
+
+			result, err	= Multinomial_coefficient ( n, k, 3 )
+			check_against_big ( t, fmt.Sprintf ( "Multinomial ( %d, %d, 3 )", n, k ), result, err, Multinomial_big ( n, k, 3 ) )
+		}
+	}
+
+	for	_, n := range	[] uint64 { 0, 1, 5, 100, 300, 416, 417, 500 }	{
+
+		var result, err	= Partition_count ( n )
+		check_against_big ( t, fmt.Sprintf ( "Partition ( %d )", n ), result, err, Partition_big ( n ) )
+	}
+}
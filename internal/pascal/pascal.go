@@ -0,0 +1,204 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+/*	Package keeps the cache of Pascal's triangle rows shared by math_tools and its subpackages.
+
+	The returned rows are the cache itself, so they must not be modified : the exported math_tools.Pascal_* functions return copies
+*/
+package	pascal
+
+import	(
+	"math"
+	"math/big"
+	"math/bits"
+	"sync"
+)
+
+/*	Rows of Pascal's triangle are computed once by additions C ( n, k ) = C ( n -1, k -1 ) + C ( n -1, k )
+	and cached for all callers, tables share the cached rows.
+	Only rows up to Cache_max are kept, rows of larger tables are computed for the call
+*/
+type cache [ T  any ] struct {
+
+	lock	sync.RWMutex
+	rows	[][] T
+	next	func ( previous  [] T )	[] T
+}
+
+var (
+	uint64_rows		= & cache [ uint64 ] { next : next_uint64 }
+	float64_rows	= & cache [ float64 ] { next : next_float64 }
+	big_rows		= & cache [ * big.Int ] { next : next_big }
+)
+
+const	(
+//	Last row of Pascal's triangle that fits uint64 ( C ( 68, 34 ) >= 2^64 )
+	Uint64_max	= 67
+
+//	Last cached row, same as the degree limit of Bernstein basis tables in the interpolation package
+	Cache_max	= 1000
+)
+
+
+//	Cached rows 0 ... n, n must not exceed Uint64_max ( the sums overflow silently )
+func Table ( n  uint )		[][] uint64	{
+
+	return	uint64_rows.table ( n )
+}
+
+//	Cached rows 0 ... n as floats, exact integers rounded once up to Uint64_max
+func Table_float ( n  uint )		[][] float64	{
+
+	return	float64_rows.table ( n )
+}
+
+//	Cached rows 0 ... n in big.Int, the values are shared too
+func Table_big ( n  uint )		[][] * big.Int	{
+
+	return	big_rows.table ( n )
+}
+
+
+/*	Row n of Pascal's triangle by the multiplicative recurrence C ( n, k +1 ) = C ( n, k ) * ( n - k ) / ( k +1 ), O ( n ) and not cached
+
+	n must not exceed Uint64_max, the product is 128 bit, so it doesn't overflow before the division
+*/
+func Row ( n  uint )		( row  [] uint64 )	{
+
+	row	= make ( [] uint64, n +1 )
+	row [ 0 ], row [ n ]	= 1, 1
+
+	for	k := uint ( 0 ) ; k < n / 2 ; k ++	{
+
+		var hi, lo	= bits.Mul64 ( row [ k ], uint64 ( n - k ) )
+
+		row [ k +1 ], _	= bits.Div64 ( hi, lo, uint64 ( k +1 ) )
+		row [ n - k -1 ]	= row [ k +1 ]
+	}
+	return
+}
+
+//	Row n as floats, not cached : exact rows up to Uint64_max, then the multiplicative recurrence ( exact while the values fit 2^53 )
+func Row_float ( n  uint )		( row  [] float64 )	{
+
+	row	= make ( [] float64, n +1 )
+
+	if	n <= Uint64_max	{
+
+		for	k, value := range	Row ( n )	{
+			row [ k ]	= float64 ( value )
+		}
+		return
+	}
+
+	row [ 0 ], row [ n ]	= 1, 1
+
+	for	k := uint ( 0 ) ; k < n / 2 ; k ++	{
+
+		var value	= row [ k ]
+
+//		Divides first when the product overflows, middle coefficients up to row 1029 fit float64
+		if	value > math.MaxFloat64 / float64 ( n - k )	{
+			value	= value / float64 ( k +1 ) * float64 ( n - k )
+		} else	{
+			value	= value * float64 ( n - k ) / float64 ( k +1 )
+		}
+		row [ k +1 ], row [ n - k -1 ]	= value, value
+	}
+	return
+}
+
+//	Row n in big.Int, exact and not cached
+func Row_big ( n  uint )		( row  [] * big.Int )	{
+
+	row	= make ( [] * big.Int, n +1 )
+	row [ 0 ], row [ n ]	= big.NewInt ( 1 ), big.NewInt ( 1 )
+
+	for	k := uint ( 0 ) ; k < n / 2 ; k ++	{
+
+		row [ k +1 ]	= new ( big.Int ).Mul ( row [ k ], new ( big.Int ).SetUint64 ( uint64 ( n - k ) ) )
+		row [ k +1 ].Quo ( row [ k +1 ], new ( big.Int ).SetUint64 ( uint64 ( k +1 ) ) )
+		row [ n - k -1 ]	= new ( big.Int ).Set ( row [ k +1 ] )
+	}
+	return
+}
+
+
+//	Rows above Cache_max extend a copy of the cached table, so they are freed with the result
+func ( self  * cache [ T ] )	table ( n  uint )		( rows  [][] T )	{
+
+	if	n > Cache_max	{
+
+		rows	= self.table ( Cache_max )
+
+		for	uint ( len ( rows ) ) <= n	{
+			rows	= append ( rows, self.next ( rows [ len ( rows ) -1 ] ) )
+		}
+		return
+	}
+
+	self.lock.RLock ()
+
+	if	uint ( len ( self.rows ) ) > n	{
+		rows	= self.rows [ : n +1 : n +1 ]
+	}
+	self.lock.RUnlock ()
+
+	if	rows != nil	{	return	}
+
+	self.lock.Lock ()
+	defer	self.lock.Unlock ()
+
+	for	uint ( len ( self.rows ) ) <= n	{
+
+		var previous	[] T
+
+		if	len ( self.rows ) > 0	{	previous	= self.rows [ len ( self.rows ) -1 ]	}
+
+		self.rows	= append ( self.rows, self.next ( previous ) )
+	}
+	return	self.rows [ : n +1 : n +1 ]
+}
+
+func next_uint64 ( previous  [] uint64 )		( row  [] uint64 )	{
+
+	row	= make ( [] uint64, len ( previous ) +1 )
+	row [ 0 ], row [ len ( previous ) ]	= 1, 1
+
+	for	k := 1 ; k < len ( previous ) ; k ++	{
+		row [ k ]	= previous [ k -1 ] + previous [ k ]
+	}
+	return
+}
+
+//	Rows up to Uint64_max are exact integers rounded once, later rows add the rounded floats
+func next_float64 ( previous  [] float64 )		( row  [] float64 )	{
+
+	row	= make ( [] float64, len ( previous ) +1 )
+
+	if	len ( previous ) <= Uint64_max	{
+
+		for	k, value := range	uint64_rows.table ( uint ( len ( previous ) ) ) [ len ( previous ) ]	{
+			row [ k ]	= float64 ( value )
+		}
+		return
+	}
+
+	row [ 0 ], row [ len ( previous ) ]	= 1, 1
+
+	for	k := 1 ; k < len ( previous ) ; k ++	{
+		row [ k ]	= previous [ k -1 ] + previous [ k ]
+	}
+	return
+}
+
+func next_big ( previous  [] * big.Int )		( row  [] * big.Int )	{
+
+	row	= make ( [] * big.Int, len ( previous ) +1 )
+	row [ 0 ], row [ len ( previous ) ]	= big.NewInt ( 1 ), big.NewInt ( 1 )
+
+	for	k := 1 ; k < len ( previous ) ; k ++	{
+		row [ k ]	= new ( big.Int ).Add ( previous [ k -1 ], previous [ k ] )
+	}
+	return
+}
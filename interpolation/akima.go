@@ -111,9 +111,30 @@ package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
 	Computes a curve coefficients for the interval where x lies : x1 <= x <= x2
 
 	Method requires at least 5 data points, err might also indicate that x is out of bounds
+	or the data is not valid ( see Akima_data_from )
 */
 func Akima_interval_curve  ( data_points  * [][] float64, x  float64 )	( interval_curve  * Akima_curve, err  error )	{
 
+	return	Akima_interval_curve_generic ( data_points, x )
+}
+
+//	Generic version of Akima_interval_curve, see Float
+func Akima_interval_curve_generic [ F  Float ] ( data_points  * [][] F, x  F )	( interval_curve  * Akima_curve_generic [ F ], err  error )	{
+
+	if	! akima_points_valid ( data_points )	{	return	interval_curve, math_tools.Arg_range_error ()	}
+
+	return	akima_interval_curve ( data_points, x )
+}
+
+//	See Akima_interval_curve
+func ( self  Akima_data )	Interval_curve  ( x  float64 )	( interval_curve  * Akima_curve, err  error )	{
+
+	return	akima_interval_curve ( & self.points, x )
+}
+
+//	Akima_interval_curve without validation of the data points
+func akima_interval_curve [ F  Float ] ( data_points  * [][] F, x  F )	( interval_curve  * Akima_curve_generic [ F ], err  error )	{
+
 	var points_len	= uint ( len ( * data_points ) )
 
 	if	points_len < 5	||
@@ -126,12 +147,12 @@ func Akima_interval_curve  ( data_points  * [][] float64, x  float64 )	( interva
 	}
 	var (
 //		Interval points where x lies : x1 <= x <= x2
-		y1, y2	float64
-		x1, x2	float64
+		y1, y2	F
+		x1, x2	F
 
 		i	uint
 //		Slopes ( 5 point ) of interval points
-		t1, t2	float64
+		t1, t2	F
 	)
 //	[ Double side search ] Find the control points where x belong
 
@@ -159,7 +180,7 @@ func Akima_interval_curve  ( data_points  * [][] float64, x  float64 )	( interva
 
 //	See section 2 :		y = p0 +  p1( x - x1 )  +  p2( x - x1 )^2  +  p3( x - x1 )^3
 
-	interval_curve	= & Akima_curve {
+	interval_curve	= & Akima_curve_generic [ F ] {
 		X1	: x1,	X2	: x2,
 		T1	: t1,	T2	: t2,	Index_x1	: i,
 	}
@@ -171,12 +192,15 @@ func Akima_interval_curve  ( data_points  * [][] float64, x  float64 )	( interva
 /*	Smoothing curve on the interval
 		x1 <=  x  <= x2
 */
-type Akima_curve struct {
+type Akima_curve	= Akima_curve_generic [ float64 ]
+
+//	Generic version of Akima_curve, see Float
+type Akima_curve_generic [ F  Float ] struct {
 
 	Index_x1	uint
-	X1, X2, T1, T2	float64
+	X1, X2, T1, T2	F
 //	Coefficients for a polynomial y
-	p0, p2, p3	float64
+	p0, p2, p3	F
 }
 
 /*	Calculates a point on a given interval	x1 <=  x  <= x2  ( bounds are not checked )
@@ -193,7 +217,7 @@ type Akima_curve struct {
 
 		t1 and t2 are 5 point slopes of the two interval points
 */
-func ( self  * Akima_curve )	Point  ( x  float64 )		float64	{
+func ( self  * Akima_curve_generic [ F ] )	Point  ( x  F )		F	{
 	var (
 		x_minus_x1      = x  -  self.X1
 		x_minus_x1_pow2	= x_minus_x1 * x_minus_x1
@@ -202,7 +226,7 @@ func ( self  * Akima_curve )	Point  ( x  float64 )		float64	{
 			self.T1 * x_minus_x1  +  self.p2 * x_minus_x1_pow2
 }
 
-func ( self  * Akima_curve )	Equal  ( other  * Akima_curve )		bool	{
+func ( self  * Akima_curve_generic [ F ] )	Equal  ( other  * Akima_curve_generic [ F ] )		bool	{
 
 	return	self.X1 == other.X1	&& self.X2 == other.X2	&&
 			self.T1 == other.T1	&& self.T2 == other.T2	&&
@@ -219,14 +243,14 @@ func ( self  * Akima_curve )	Equal  ( other  * Akima_curve )		bool	{
 
 	Returns nil if this interval is the last one
 */
-func ( self  * Akima_curve )	Next_curve  ( data_points  * [][] float64 )		( next  * Akima_curve )	{
+func ( self  * Akima_curve_generic [ F ] )	Next_curve  ( data_points  * [][] F )		( next  * Akima_curve_generic [ F ] )	{
 
 	var points_len	= uint ( len ( * data_points ) )
 	var new_i_x2	= self.Index_x1 +2
 
 	if	new_i_x2 >= points_len	{	return	nil	}
 
-	next	= new ( Akima_curve )
+	next	= new ( Akima_curve_generic [ F ] )
 	next.Index_x1	= self.Index_x1 +1
 
 	next.X1, next.T1	= self.X2 ,	self.T2
@@ -242,13 +266,13 @@ func ( self  * Akima_curve )	Next_curve  ( data_points  * [][] float64 )		( next
 
 	Returns nil if this interval is the first one
 */
-func ( self  * Akima_curve )	Prev_curve  ( data_points  * [][] float64 )		( prev  * Akima_curve )	{
+func ( self  * Akima_curve_generic [ F ] )	Prev_curve  ( data_points  * [][] F )		( prev  * Akima_curve_generic [ F ] )	{
 
 	var points_len	= uint ( len ( * data_points ) )
 //	Care : Uint 0 -1 ~ undefined
 	if	self.Index_x1 == 0	{	return	nil	}
 
-	prev	= new ( Akima_curve )
+	prev	= new ( Akima_curve_generic [ F ] )
 	prev.Index_x1	= self.Index_x1 -1
 
 	prev.X1	= ( * data_points ) [ prev.Index_x1 ][ 0 ]
@@ -258,7 +282,7 @@ func ( self  * Akima_curve )	Prev_curve  ( data_points  * [][] float64 )		( prev
 	prev.set_coefficients ( ( * data_points ) [ prev.Index_x1 ][ 1 ] , ( * data_points ) [ self.Index_x1 ][ 1 ] ) ;	return
 }
 
-func ( self  * Akima_curve )	set_coefficients ( y1, y2  float64 )	{
+func ( self  * Akima_curve_generic [ F ] )	set_coefficients ( y1, y2  F )	{
 	var	(
 		x2_minus_x1	= self.X2 - self.X1
 		y2_minus_y1	= y2 - y1
@@ -273,10 +297,10 @@ func ( self  * Akima_curve )	set_coefficients ( y1, y2  float64 )	{
 }
 
 
-func slope_five_point  ( data_points  * [][] float64, points_len, i  uint )		float64	{
+func slope_five_point [ F  Float ] ( data_points  * [][] F, points_len, i  uint )		F	{
 
 //	   	2 point Slopes
-	var	m12, m23, m34, m45	,	x1, x2, x3, x4, x5	,	y1, y2, y3, y4, y5	float64
+	var	m12, m23, m34, m45	,	x1, x2, x3, x4, x5	,	y1, y2, y3, y4, y5	F
 
 	x3, y3	= ( * data_points ) [ i ][ 0 ] ,	( * data_points ) [ i ][ 1 ]
 
@@ -350,8 +374,8 @@ func slope_five_point  ( data_points  * [][] float64, points_len, i  uint )		flo
 	if	m12 == m23	&& m34 == m45	{	return	( m23 + m34 ) / 2.0
 	} else {
 		var	(
-			m45_minus_m34	= math.Abs ( m45 - m34 )
-			m23_minus_m12	= math.Abs ( m23 - m12 )
+			m45_minus_m34	= F ( math.Abs ( float64 ( m45 - m34 ) ) )
+			m23_minus_m12	= F ( math.Abs ( float64 ( m23 - m12 ) ) )
 		)
 		return	( m45_minus_m34 * m23  +  m23_minus_m12 * m34 )  /  ( m45_minus_m34 + m23_minus_m12 )
 	}
@@ -355,4 +355,50 @@ func ExampleAkima_interval_curve_1  ()	{
 //y ( 50 ) = 50, got 50
 //y ( 35 ) = 35, got 35
 //y ( 20 ) = 20, got 20
-}
\ No newline at end of file
+}
+func Test_Akima_interval_curve_generic ( t  * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		data_points	= make ( [][] float64, 12 )
+		data32		= make ( [][] float32, 12 )
+	)
+
+	for	i := range	data_points	{
+
+		var x	= float64 ( i ) / 11.0
+
+		data_points [ i ]	= [] float64 { x, math.Sin ( x ) }
+		data32 [ i ]		= [] float32 { float32 ( x ), float32 ( math.Sin ( x ) ) }
+	}
+
+	for	_, x := range	[] float64 { 0.0, 0.15, 0.5, 0.73, 1.0 }	{
+
+		var (
+			curve, err		= Akima_interval_curve ( & data_points, x )
+			curve32, err32	= Akima_interval_curve_generic ( & data32, float32 ( x ) )
+		)
+
+		if	err != nil	|| err32 != nil	{
+			t.Error ( err, err32 )
+			t.FailNow ()
+		}
+
+		if	curve.Index_x1 != curve32.Index_x1	||
+			fmt.Sprintf ( "%.4f", curve32.Point ( float32 ( x ) ) ) != fmt.Sprintf ( "%.4f", curve.Point ( x ) )	{
+
+			t.Errorf ( "Akima_interval_curve_generic [ float32 ] at %.4f expected = %.4f, got : %.4f", x, curve.Point ( x ), curve32.Point ( float32 ( x ) ) )
+		}
+
+		var bezier	= curve32.Bezier ()
+
+		if	fmt.Sprintf ( "%.4f", bezier [ 3 ][ 1 ] ) != fmt.Sprintf ( "%.4f", data_points [ curve.Index_x1 +1 ][ 1 ] )	{
+			t.Errorf ( "Bezier of the float32 curve expected to end at %.4f, got : %v", data_points [ curve.Index_x1 +1 ][ 1 ], bezier )
+		}
+	}
+
+	if	_, err := Akima_interval_curve_generic ( & [][] float32 { { 0, 0 }, { 1, 1 } }, 0.5 ) ; err == nil	{
+		t.Error ( "There are less than 5 data points but there is no error" )
+	}
+}
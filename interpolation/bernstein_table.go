@@ -0,0 +1,121 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "github.com/sjbog/math_tools" )
+
+/*	Bernstein weights of one degree precomputed for a fixed set of offsets
+
+	Sampling a curve at the offsets is a matrix product :
+
+		result [ row ]	= Σ weight ( row, i ) * P_i,	0 <= i <= degree
+
+	so the table is built once and reused for every curve of the degree ( e.g. the same offsets on every frame ).
+	The weights depend on the degree and offsets, so they are read only ( see Degree and Offsets )
+*/
+type Bernstein_table struct {
+	degree	uint
+	offsets	[] float64
+
+//	Row major : len ( offsets ) rows of degree +1 weights
+	weights	[] float64
+}
+
+//	Precomputes Bernstein_basis ( degree, i, offset ) for every offset and 0 <= i <= degree, offsets are copied
+func New_bernstein_table ( degree  uint, offsets  [] float64 )		( table  * Bernstein_table )	{
+
+	var columns	= int ( degree ) +1
+
+	table	= & Bernstein_table {
+		degree	: degree,
+		offsets	: append ( [] float64 ( nil ), offsets... ),
+		weights	: make ( [] float64, len ( offsets ) * columns ),
+	}
+
+	for	row, offset := range	offsets	{
+		for	i := 0 ; i < columns ; i ++	{
+			table.weights [ row * columns + i ]	= Bernstein_basis ( degree, uint ( i ), offset )
+		}
+	}
+	return
+}
+
+func ( self  * Bernstein_table )	Degree ()		uint	{	return	self.degree	}
+
+//	Number of the offsets ( rows of the table )
+func ( self  * Bernstein_table )	Len ()		int	{	return	len ( self.offsets )	}
+
+//	Copy of the offsets
+func ( self  * Bernstein_table )	Offsets ()		[] float64	{
+
+	return	append ( [] float64 ( nil ), self.offsets... )
+}
+
+//	Bernstein weight of the control point index at the offset Offsets () [ row ]
+func ( self  * Bernstein_table )	Weight ( row  int, index  uint )		float64	{
+
+	return	self.weights [ row * ( int ( self.degree ) +1 ) + int ( index ) ]
+}
+
+//	Points of the curve at every offset of the table, see Points_to
+func ( self  * Bernstein_table )	Points ( control_points  * [][] float64 )		( result  [][] float64, err  error )	{
+
+	if	len ( * control_points ) == 0	{	return	result, math_tools.Arg_range_error ()	}
+
+	result	= make ( [][] float64, len ( self.offsets ) )
+
+	for	row, dimensions := 0, len ( ( * control_points ) [ 0 ] ) ; row < len ( result ) ; row ++	{
+		result [ row ]	= make ( [] float64, dimensions )
+	}
+	return	result, self.Points_to ( control_points, result )
+}
+
+/*	Writes points of the curve at every offset of the table into the preallocated result, nothing is allocated
+
+	Return error if the curve degree differs from the table one, rows are ragged or result doesn't have Len () rows of the curve dimensions
+*/
+func ( self  * Bernstein_table )	Points_to ( control_points  * [][] float64, result  [][] float64 )		error	{
+
+	var columns	= int ( self.degree ) +1
+
+	if	len ( * control_points ) != columns	{	return	math_tools.Arg_range_error ()	}
+
+	var dimensions	= len ( ( * control_points ) [ 0 ] )
+
+	for	_, point := range	* control_points	{
+		if	len ( point ) != dimensions	{	return	math_tools.Arg_range_error ()	}
+	}
+
+	if	! sample_buffer_fits ( result, len ( self.offsets ), dimensions )	{
+		return	math_tools.Arg_range_error ()
+	}
+
+	for	row, point := range	result	{
+
+		var weights	= self.weights [ row * columns : ( row +1 ) * columns ]
+
+		for	di := range	point	{
+			point [ di ]	= 0
+		}
+
+		for	i, weight := range	weights	{
+			for	di, value := range	( * control_points ) [ i ]	{
+				point [ di ]	+= weight * value
+			}
+		}
+	}
+	return	nil
+}
+
+//	Points of every curve at every offset of the table : result [ c ][ row ] is the point of curves [ c ] at Offsets () [ row ]
+func ( self  * Bernstein_table )	Curves_points ( curves  [][][] float64 )		( result  [][][] float64, err  error )	{
+
+	result	= make ( [][][] float64, len ( curves ) )
+
+	for	c := range	curves	{
+
+		if	result [ c ], err = self.Points ( & curves [ c ] ) ; err != nil	{
+			return	nil, err
+		}
+	}
+	return
+}
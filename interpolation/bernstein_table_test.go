@@ -0,0 +1,111 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"testing"
+)
+
+
+func Test_Bernstein_table ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var offsets	= make ( [] float64, 64 )
+
+	for	i := range	offsets	{
+		offsets [ i ]	= float64 ( i ) / 63.0
+	}
+
+	var cases	= [][][] float64 {
+		{ { 1.0, 2.0, 3.0 } },
+		{ { 0.0, 0.0 }, { 4.0, 2.0 } },
+		{ { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },
+		{ { -2.0, 0.0 }, { -1.0, 2.0 }, { 0.0, 0.0 }, { 1.0, -2.0 }, { 2.0, 0.0 }, { 3.0, 1.0 } },
+	}
+
+	for	_, control_points := range	cases	{
+
+		var (
+			table		= New_bernstein_table ( uint ( len ( control_points ) -1 ), offsets )
+			result, err	= table.Points ( & control_points )
+		)
+
+		if	err != nil	{
+			t.Error ( err )
+			t.FailNow ()
+		}
+
+		for	row, offset := range	offsets	{
+
+			var expected	= Bezier_point ( & control_points, offset )
+
+			for	di := range	expected	{
+
+				if	fmt.Sprintf ( "%.4f", result [ row ][ di ] ) != fmt.Sprintf ( "%.4f", expected [ di ] )	{
+					t.Errorf ( "Table points of %v at %.4f expected = %v, got : %v", control_points, offset, expected, result [ row ] )
+					t.FailNow ()
+				}
+			}
+		}
+	}
+
+	var (
+		table	= New_bernstein_table ( 3, offsets )
+		all, err	= table.Curves_points ( [][][] float64 { cases [ 2 ], cases [ 2 ] } )
+	)
+
+	if	err != nil	|| len ( all ) != 2	|| fmt.Sprint ( all [ 0 ] ) != fmt.Sprint ( all [ 1 ] )	{
+		t.Errorf ( "Curves_points expected two equal rows, got : %v, %v", all, err )
+	}
+
+	if	fmt.Sprintf ( "%.4f", table.Weight ( 21, 1 ) ) != fmt.Sprintf ( "%.4f", Bernstein_basis ( 3, 1, offsets [ 21 ] ) )	{
+		t.Errorf ( "Weight ( 21, 1 ) expected = %.4f, got : %.4f", Bernstein_basis ( 3, 1, offsets [ 21 ] ), table.Weight ( 21, 1 ) )
+	}
+
+//	Offsets are copied both ways
+	var copied	= table.Offsets ()
+
+	copied [ 21 ], offsets [ 21 ]	= 2.0, 2.0
+
+	if	table.Degree () != 3	|| table.Len () != 64	|| table.Offsets () [ 21 ] != 21.0 / 63.0	{
+		t.Errorf ( "Table expected degree 3, 64 offsets and offset 21 = %.4f, got : %d, %d, %.4f", 21.0 / 63.0, table.Degree (), table.Len (), table.Offsets () [ 21 ] )
+	}
+
+	if	_, err := table.Points ( & cases [ 3 ] ) ; err == nil	{
+		t.Error ( "Curve degree differs from the table one but there is no error" )
+	}
+
+	if	err := table.Points_to ( & cases [ 2 ], make ( [][] float64, 3 ) ) ; err == nil	{
+		t.Error ( "Result buffer is too small but there is no error" )
+	}
+
+	if	_, err := table.Curves_points ( [][][] float64 { cases [ 2 ], { { 0.0 }, { 1.0, 2.0 }, { 0.0 }, { 1.0 } } } ) ; err == nil	{
+		t.Error ( "Rows are ragged but there is no error" )
+	}
+}
+
+func Benchmark_Bernstein_table_points_to ( b * testing.B )	{
+
+	var (
+		control_points	= [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } }
+		offsets	= make ( [] float64, 64 )
+	)
+
+	for	i := range	offsets	{
+		offsets [ i ]	= float64 ( i ) / 63.0
+	}
+
+	var (
+		table	= New_bernstein_table ( 3, offsets )
+		result	= new_sample_buffer ( len ( offsets ), 2 )
+	)
+
+	b.ReportAllocs ()
+
+	for	i := 0 ; i < b.N ; i ++	{
+		table.Points_to ( & control_points, result )
+	}
+}
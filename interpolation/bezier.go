@@ -3,7 +3,7 @@
 
 /*	Package provides interpolation and curves smoothing mathematical functions and tools.
 */
-package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" ; "github.com/sjbog/math_tools/internal/pascal" )
 
 /*	Calculates the point ( by offset percent ) from a Bézier curve
 
@@ -15,12 +15,37 @@ package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
 
 	Return
 
-		result	: a point ( dimensions are the same as P0 ) from a Bezier curve on a specified offset ( percentage ) position
+		result	: a new point ( dimensions are the same as P0 ) from a Bezier curve on a specified offset ( percentage ) position
 
-	One should use Goroutines for calculating curves
+	One should use Goroutines for calculating curves, see Bezier_sample
 */
 func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result  [] float64 )	{
 
+	return	Bezier_point_to_generic ( control_points, offset, nil )
+}
+
+//	Generic version of Bezier_point, see Float
+func Bezier_point_generic [ F  Float ] ( control_points  * [][] F, offset  F )		( result  [] F )	{
+
+	return	Bezier_point_to_generic ( control_points, offset, nil )
+}
+
+/*	Calculates the point of a Bézier curve into the destination, see Bezier_point
+
+	The point is written to destination [ : dimensions ] and returned, so nothing is allocated if cap ( destination ) fits the dimensions
+	( a new slice is allocated otherwise, like append does ). If the destination overlaps a control point ( e.g. it is one of them ),
+	a new slice is allocated too, so the returned point never shares memory with the control points
+*/
+func Bezier_point_to ( control_points  * [][] float64, offset  float64, destination  [] float64 )		( result  [] float64 )	{
+
+	return	Bezier_point_to_generic ( control_points, offset, destination )
+}
+
+//	Generic version of Bezier_point_to, see Float
+func Bezier_point_to_generic [ F  Float ] ( control_points  * [][] F, offset  F, destination  [] F )		( result  [] F )	{
+
+	if	control_points == nil	{	return	result	}
+
 	var points_len	= uint ( len ( * control_points ) )
 
 	if	points_len == 0		{	return	result	}
@@ -29,17 +54,15 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 	points_len --
 
 	var (
-		berstein_basis	float64
+		berstein_basis	F
 		degree			= len ( ( * control_points ) [ 0 ] )
 		offset_complementary	= 1.0 - offset
 	)
 
-//	Fill resulting point from P0 :	P0 * ( 1 - t )
-
-	berstein_basis	= math.Pow ( offset_complementary, float64 ( points_len ) )
-
-	for	di := 0 ; di < degree ; di ++	{
-		result	= append ( result,	( * control_points ) [ 0 ][ di ] * berstein_basis )
+	if	cap ( destination ) >= degree	&& ! points_overlap ( * control_points, destination [ : degree ] )	{
+		result	= destination [ : degree ]
+	} else	{
+		result	= make ( [] F, degree )
 	}
 
 //	Possible optimization : vector / matrix computation of point's dimensions
@@ -62,12 +85,14 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 	switch	points_len	{
 
 //		P0 only	: result = P0
-		case 0 :	return	( * control_points ) [ 0 ]
+		case 0 :
+			copy ( result, ( * control_points ) [ 0 ] )
+			return
 
 //		Linear	: result = ( 1 - t ) * P0  +  t * P1
 		case 1 :
 
-			var P0, P1	float64
+			var P0, P1	F
 
 			for	di := 0 ; di < degree ; di ++	{
 
@@ -80,7 +105,7 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 		case 2 :
 
 			var (
-				P0, P1, P2		float64
+				P0, P1, P2		F
 
 				offset_Mul_complementary	= offset_complementary * offset
 				offset_Pow2					= offset * offset
@@ -102,7 +127,7 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 		case 3 :
 
 			var (
-				P0, P1, P2, P3		float64
+				P0, P1, P2, P3		F
 
 				offset_Mul_complementary_Pow2	= offset * offset_complementary * offset_complementary
 				offset_Pow2_Mul_complementary	= offset * offset * offset_complementary
@@ -124,16 +149,35 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 	}
 
 
-	for	point_i, binomial_coeff := uint ( 1 ), uint ( 1 )
-		point_i <= points_len
-		point_i ++	{
+//	Fill resulting point from P0 :	P0 * ( 1 - t )^n
 
+	berstein_basis	= F ( math.Pow ( float64 ( offset_complementary ), float64 ( points_len ) ) )
 
-		binomial_coeff	= binomial_coeff * ( points_len - point_i + 1 ) / point_i
+	for	di := 0 ; di < degree ; di ++	{
+		result [ di ]	= ( * control_points ) [ 0 ][ di ] * berstein_basis
+	}
+
+	if	points_len > bernstein_log_degree	{
 
-		berstein_basis	= float64 ( binomial_coeff ) *
-			math.Pow ( offset, float64 ( point_i ) ) *
-			math.Pow ( offset_complementary, float64 ( points_len - point_i ) )
+		for	point_i := uint ( 1 ) ; point_i <= points_len ; point_i ++	{
+
+			berstein_basis	= Bernstein_basis_generic ( points_len, point_i, offset )
+
+			for	di := 0 ; di < degree ; di ++	{
+				result [ di ]	+= ( * control_points ) [ point_i ][ di ]  *  berstein_basis
+			}
+		}
+		return
+	}
+
+//	Binomial coefficients of the row, cached and shared ( see internal/pascal ), only read here
+	var binomial_row	= pascal.Table_float ( points_len ) [ points_len ]
+
+	for	point_i := uint ( 1 ) ; point_i <= points_len ; point_i ++	{
+
+		berstein_basis	= F ( binomial_row [ point_i ] ) *
+			F ( math.Pow ( float64 ( offset ), float64 ( point_i ) ) ) *
+			F ( math.Pow ( float64 ( offset_complementary ), float64 ( points_len - point_i ) ) )
 
 
 		for	di := 0 ; di < degree ; di ++	{
@@ -145,6 +189,26 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 }
 
 
+//	Whether the slice shares an element with any of the points, the points are read after the result is partially written
+func points_overlap [ F  Float ] ( points  [][] F, slice  [] F )		bool	{
+
+	if	len ( slice ) == 0	{	return	false	}
+
+	for	_, point := range	points	{
+
+		if	len ( point ) == 0	{	continue	}
+
+		for	i := range	point	{
+			if	& point [ i ] == & slice [ 0 ]	{	return	true	}
+		}
+		for	i := range	slice	{
+			if	& slice [ i ] == & point [ 0 ]	{	return	true	}
+		}
+	}
+	return	false
+}
+
+
 /*	Bernstein basis polynomials of degree n
 
 	Polynomials on http://mathworld.wolfram.com/BernsteinPolynomial.html
@@ -153,9 +217,17 @@ func Bezier_point ( control_points  * [][] float64, offset  float64 )		( result
 		control_points_num	: total number of control points
 		control_point_index	: ( 0 based ) index of the control point of interest [ 0 <= control_point_index < control_points_num ]
 		offset	: 0.0 <= offset <= 1.0 , see func Bezier_point
+
+	Degrees above bernstein_log_degree are computed in log space ( see math_tools.LogBinomial ), so they don't overflow
 */
 func Bernstein_basis ( control_points_num, control_point_index uint, offset float64 )		float64	{
 
+	return	Bernstein_basis_generic ( control_points_num, control_point_index, offset )
+}
+
+//	Generic version of Bernstein_basis, see Float
+func Bernstein_basis_generic [ F  Float ] ( control_points_num, control_point_index uint, offset F )		F	{
+
 	if	control_points_num == 0	{	return 1.0	}
 
 	if	control_points_num == control_point_index	{
@@ -183,7 +255,7 @@ func Bernstein_basis ( control_points_num, control_point_index uint, offset floa
 
 		case 1 :
 			var t	= 1.0 - offset
-			offset	= float64 ( control_points_num ) * offset
+			offset	= F ( control_points_num ) * offset
 
 			for	; control_points_num > 1 ; control_points_num --	{
 				offset	= t * offset
@@ -203,7 +275,7 @@ func Bernstein_basis ( control_points_num, control_point_index uint, offset floa
 
 	if	control_points_num == control_point_index +1	{
 
-		var t	= float64 ( control_points_num ) * ( 1.0 - offset ) * offset
+		var t	= F ( control_points_num ) * ( 1.0 - offset ) * offset
 
 		for	; control_point_index > 1 ; control_point_index --	{
 			t	= t * offset
@@ -213,7 +285,99 @@ func Bernstein_basis ( control_points_num, control_point_index uint, offset floa
 	}
 
 
-	return	float64 ( math_tools.Binomial_coefficient ( control_points_num, control_point_index ) ) *
-		math.Pow ( offset, float64 ( control_point_index ) ) *
-		math.Pow ( ( 1.0 - offset ), float64 ( control_points_num - control_point_index ) )
-}
\ No newline at end of file
+//	Binomial coefficients of large degrees overflow float64 while the powers underflow
+	if	control_points_num > bernstein_log_degree	{
+
+		return	F ( math.Exp (
+			math_tools.LogBinomial ( float64 ( control_points_num ), float64 ( control_point_index ) ) +
+			float64 ( control_point_index ) * math.Log ( float64 ( offset ) ) +
+			float64 ( control_points_num - control_point_index ) * math.Log ( float64 ( 1.0 - offset ) ),
+		))
+	}
+
+	return	F ( pascal.Table_float ( control_points_num ) [ control_points_num ][ control_point_index ] ) *
+		F ( math.Pow ( float64 ( offset ), float64 ( control_point_index ) ) ) *
+		F ( math.Pow ( float64 ( 1.0 - offset ), float64 ( control_points_num - control_point_index ) ) )
+}
+
+//	Degrees above the limit compute Bernstein basis in log space ( C ( 1030, 515 ) overflows float64 ), the cached binomial rows end there too
+const	bernstein_log_degree	= pascal.Cache_max
+
+//	Binomial coefficient as a float, exact while it fits uint64, see math_tools.BinomialFloat
+func binomial_float ( n, k  uint )		float64	{
+
+	return	math_tools.BinomialFloat ( float64 ( n ), float64 ( k ) )
+}
+
+/*	Computes control points of the derivative ( hodograph ) of a Bézier curve
+
+	The derivative of a curve of degree n is a curve of degree n -1 with control points
+
+		D_i = n * ( P_i+1 - P_i )
+
+	So the derivative at the offset is Bezier_point ( derivative, offset ) = Σ D_i * Bernstein_basis ( n -1, i, offset ).
+	Returns nil for a single point curve ( derivative is zero )
+*/
+func Bezier_derivative ( control_points  * [][] float64 )		( result  [][] float64 )	{
+
+	var points_len	= len ( * control_points )
+
+	if	points_len < 2	{	return	result	}
+
+	var (
+		degree		= float64 ( points_len -1 )
+		dimensions	= len ( ( * control_points ) [ 0 ] )
+	)
+	result	= make ( [][] float64, points_len -1 )
+
+	for	i := range	result	{
+
+		result [ i ]	= make ( [] float64, dimensions )
+
+		for	di := 0 ; di < dimensions ; di ++	{
+			result [ i ][ di ]	= degree * ( ( * control_points ) [ i +1 ][ di ] - ( * control_points ) [ i ][ di ] )
+		}
+	}
+	return
+}
+
+
+/*	Splits a Bézier curve at the offset into two curves of the same degree ( de Casteljau algorithm )
+
+	left	: control points of the part [ 0.0, offset ]
+	right	: control points of the part [ offset, 1.0 ]
+
+	Both curves are reparametrised to 0.0 <= offset <= 1.0, input points are not modified
+*/
+func Bezier_split ( control_points  * [][] float64, offset  float64 )		( left, right  [][] float64 )	{
+
+	var points_len	= len ( * control_points )
+
+	if	points_len == 0	{	return	}
+
+	var (
+		dimensions	= len ( ( * control_points ) [ 0 ] )
+		offset_complementary	= 1.0 - offset
+
+//		Working row of de Casteljau triangle
+		row		= make ( [][] float64, points_len )
+	)
+	left, right	= make ( [][] float64, points_len ), make ( [][] float64, points_len )
+
+	for	i := range	row	{
+		row [ i ]	= append ( [] float64 ( nil ), ( * control_points ) [ i ]... )
+	}
+
+	for	level := 0 ; level < points_len ; level ++	{
+
+		left [ level ]	= append ( [] float64 ( nil ), row [ 0 ]... )
+		right [ points_len -1 - level ]	= append ( [] float64 ( nil ), row [ points_len -1 - level ]... )
+
+		for	i := 0 ; i < points_len -1 - level ; i ++	{
+			for	di := 0 ; di < dimensions ; di ++	{
+				row [ i ][ di ]	= row [ i ][ di ] * offset_complementary + row [ i +1 ][ di ] * offset
+			}
+		}
+	}
+	return
+}
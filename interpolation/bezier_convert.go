@@ -0,0 +1,153 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "github.com/sjbog/math_tools" )
+
+/*	Control points of a Bézier curve from a polynomial in power ( monomial ) form
+
+		B( t ) = Σ a_k t^k	, 0 <= k <= n
+
+	Coefficients a_k are vectors of the same dimensions, conversion is exact :
+
+		P_i = Σ C( i, k ) / C( n, k ) * a_k	, where k <= i
+*/
+func Bezier_from_power ( coefficients  [][] float64 )		( result  [][] float64 )	{
+
+	var size	= uint ( len ( coefficients ) )
+
+	if	size == 0	{	return	result	}
+
+	var (
+		degree		= size -1
+		dimensions	= len ( coefficients [ 0 ] )
+	)
+	result	= make ( [][] float64, size )
+
+	for	i := uint ( 0 ) ; i <= degree ; i ++	{
+
+		result [ i ]	= make ( [] float64, dimensions )
+
+		for	k := uint ( 0 ) ; k <= i ; k ++	{
+
+			var weight	= binomial_float ( i, k ) / binomial_float ( degree, k )
+
+			for	di := range	result [ i ]	{
+				result [ i ][ di ]	+= weight * coefficients [ k ][ di ]
+			}
+		}
+	}
+	return
+}
+
+/*	Polynomial coefficients ( power form, see Bezier_from_power ) of a Bézier curve
+
+		a_k = C( n, k ) * Σ ( -1 )^( k - i ) * C( k, i ) * P_i	, where i <= k
+*/
+func Bezier_to_power ( control_points  * [][] float64 )		( result  [][] float64 )	{
+
+	var points_len	= uint ( len ( * control_points ) )
+
+	if	points_len == 0	{	return	result	}
+
+	var (
+		degree		= points_len -1
+		dimensions	= len ( ( * control_points ) [ 0 ] )
+	)
+	result	= make ( [][] float64, points_len )
+
+	for	k := uint ( 0 ) ; k <= degree ; k ++	{
+
+		result [ k ]	= make ( [] float64, dimensions )
+
+		for	i := uint ( 0 ) ; i <= k ; i ++	{
+
+			var weight	= binomial_float ( degree, k ) * binomial_float ( k, i )
+
+			if	( k - i ) % 2 == 1	{	weight	= -weight	}
+
+			for	di := range	result [ k ]	{
+				result [ k ][ di ]	+= weight * ( * control_points ) [ i ][ di ]
+			}
+		}
+	}
+	return
+}
+
+
+/*	Cubic Bézier curve from Hermite form : end points and derivatives ( by the curve offset 0.0 <= t <= 1.0 )
+
+		P0 = start,	P1 = start + start_tangent / 3,	P2 = end - end_tangent / 3,	P3 = end
+*/
+func Bezier_from_hermite ( start, start_tangent, end, end_tangent  [] float64 )		[][] float64	{
+
+	return	[][] float64 {
+		append ( [] float64 ( nil ), start... ),
+		add_scaled ( start, start_tangent, 1.0 / 3.0 ),
+		add_scaled ( end, end_tangent, -1.0 / 3.0 ),
+		append ( [] float64 ( nil ), end... ),
+	}
+}
+
+/*	Hermite form of a cubic Bézier curve, reverse of Bezier_from_hermite
+
+	Returns an error if the curve is not cubic
+*/
+func Bezier_to_hermite ( control_points  * [][] float64 )		( start, start_tangent, end, end_tangent  [] float64, err  error )	{
+
+	if	len ( * control_points ) != 4	{	return	start, start_tangent, end, end_tangent, math_tools.Arg_range_error ()	}
+
+	var points	= * control_points
+
+	start, end	= append ( [] float64 ( nil ), points [ 0 ]... ), append ( [] float64 ( nil ), points [ 3 ]... )
+
+	return	start, segment_start_derivative ( points ), end, segment_end_derivative ( points ), nil
+}
+
+
+/*	Cubic Bézier curve ( x, y ) of the Akima interval X1 <= x <= X2
+
+	The polynomial y = p0 + T1 ( x - X1 ) + p2 ( x - X1 )^2 + p3 ( x - X1 )^3 with x = X1 + t ( X2 - X1 )
+	is converted from power form ( see Bezier_from_power ), x is linear in t :
+
+		Bezier_point ( curve, t ) == [ x, self.Point ( x ) ]
+*/
+func ( self  * Akima_curve_generic [ F ] )	Bezier ()		( result  [][] F )	{
+
+	var (
+		width	= float64 ( self.X2 - self.X1 )
+
+		curve	= Bezier_from_power ( [][] float64 {
+			[] float64 { float64 ( self.X1 ), float64 ( self.p0 ) },
+			[] float64 { width, float64 ( self.T1 ) * width },
+			[] float64 { 0.0, float64 ( self.p2 ) * width * width },
+			[] float64 { 0.0, float64 ( self.p3 ) * width * width * width },
+		})
+	)
+	result	= make ( [][] F, len ( curve ) )
+
+	for	i, point := range	curve	{
+		result [ i ]	= [] F { F ( point [ 0 ] ), F ( point [ 1 ] ) }
+	}
+	return
+}
+
+/*	Akima spline of the data points as a Bézier path, one cubic segment per interval
+
+	The path can be evaluated, written as SVG path data ( Svg_path_data ) or processed by other Bézier tools.
+	Returns an error if there are less than 5 data points, a point has less than 2 coordinates or x is not increasing
+*/
+func Akima_path ( data_points  * [][] float64 )		( path  Path, err  error )	{
+
+	if	! akima_points_valid ( data_points )	{	return	path, math_tools.Arg_range_error ()	}
+
+	var curve	* Akima_curve
+
+	if	curve, err	= Akima_interval_curve ( data_points, ( * data_points ) [ 0 ][ 0 ] ) ; err != nil	{
+		return
+	}
+
+	for	; curve != nil ; curve	= curve.Next_curve ( data_points )	{
+		path.Segments	= append ( path.Segments, curve.Bezier () )
+	}
+	return
+}
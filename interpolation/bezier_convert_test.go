@@ -0,0 +1,145 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+func Test_Bezier_power ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [...] struct	{
+		points, power	[][] float64
+	}	{
+//		B( t ) = ( 2t, 4t - 4t^2 )
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } },
+			[][] float64 { { 0.0, 0.0 }, { 2.0, 4.0 }, { 0.0, -4.0 } },
+		},
+//		y = x^3
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0 / 3.0, 0.0 }, { 2.0 / 3.0, 0.0 }, { 1.0, 1.0 } },
+			[][] float64 { { 0.0, 0.0 }, { 1.0, 0.0 }, { 0.0, 0.0 }, { 0.0, 1.0 } },
+		},
+		{	[][] float64 { { 0.0, 0.0, 1.0 }, { 1.0, 3.0, 0.0 }, { 2.0, -1.0, 2.0 }, { 3.0, 3.0, -1.0 }, { 4.0, 0.0, 0.5 } },
+			nil,
+		},
+	}
+
+	for	ci, c := range	cases	{
+
+		var power	= Bezier_to_power ( & c.points )
+
+		if	c.power != nil	&& fmt.Sprintf ( "%.6f", power ) != fmt.Sprintf ( "%.6f", c.power )	{
+			t.Errorf ( "Case %d : expected %v, got : %v", ci, c.power, power )
+		}
+
+		if	result := Bezier_from_power ( power ) ; fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", c.points )	{
+			t.Errorf ( "Case %d : round trip expected %v, got : %v", ci, c.points, result )
+		}
+
+//		Polynomial value is the curve point
+		for	i := 0 ; i <= 10 ; i ++	{
+
+			var (
+				offset		= float64 ( i ) / 10.0
+				expected	= Bezier_point ( & c.points, offset )
+				result		= make ( [] float64, len ( expected ) )
+			)
+
+			for	k, coefficient := range	power	{
+				for	di := range	result	{
+					result [ di ]	+= coefficient [ di ] * math.Pow ( offset, float64 ( k ) )
+				}
+			}
+
+			if	fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", expected )	{
+				t.Errorf ( "Case %d at %v : expected %v, got : %v", ci, offset, expected, result )
+				break
+			}
+		}
+	}
+}
+
+func Test_Bezier_hermite ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		cubic	= [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } }
+
+		start, start_tangent, end, end_tangent, err	= Bezier_to_hermite ( & cubic )
+	)
+
+	if	err != nil	|| fmt.Sprint ( start, start_tangent, end, end_tangent ) != "[0 0] [0 24] [16 0] [0 -24]"	{
+		t.Errorf ( "Expected [0 0] [0 24] [16 0] [0 -24], got : %v %v %v %v ( %v )", start, start_tangent, end, end_tangent, err )
+	}
+
+	if	result := Bezier_from_hermite ( start, start_tangent, end, end_tangent ) ; fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", cubic )	{
+		t.Errorf ( "Round trip expected %v, got : %v", cubic, result )
+	}
+
+	if	_, _, _, _, err = Bezier_to_hermite ( & [][] float64 { { 0.0 }, { 1.0 } } ) ; err == nil	{
+		t.Error ( "Curve is not cubic but there is no error" )
+	}
+}
+
+func Test_Akima_bezier ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var control_points	[][] float64
+
+	for	x := 0.0 ; x <= 2 * math.Pi ; x += math.Pi / 10.0	{
+		control_points	= append ( control_points, [] float64 { x, math.Sin ( x ) } )
+	}
+
+	var path, err	= Akima_path ( & control_points )
+
+	if	err != nil	|| len ( path.Segments ) != len ( control_points ) -1	{
+		t.Error ( "Akima path ", len ( path.Segments ), err )
+		t.FailNow ()
+	}
+
+	for	i, segment := range	path.Segments	{
+
+		var curve, _	= Akima_interval_curve ( & control_points, control_points [ i ][ 0 ] + 1e-9 )
+
+		for	step := 0 ; step <= 10 ; step ++	{
+
+			var (
+				point	= Bezier_point ( & segment, float64 ( step ) / 10.0 )
+				x		= curve.X1 + ( curve.X2 - curve.X1 ) * float64 ( step ) / 10.0
+			)
+
+			if	fmt.Sprintf ( "%.9f", point ) != fmt.Sprintf ( "%.9f", [] float64 { x, curve.Point ( x ) } )	{
+				t.Errorf ( "Interval %d : expected %v, got : %v", i, [] float64 { x, curve.Point ( x ) }, point )
+				t.FailNow ()
+			}
+		}
+	}
+
+	if	path.Continuity ( 3, 1e-9 ) != Continuity_C1	{
+		t.Errorf ( "Akima path should be C1, got : %v", path.Continuity ( 3, 1e-9 ) )
+	}
+
+	if	data, err := Svg_path_data ( [] Path { path }, 3 ) ; err != nil	|| data [ : 6 ] != "M0 0C."	{
+		t.Errorf ( "SVG path data %q ( %v )", data, err )
+	}
+
+	if	_, err = Akima_path ( & [][] float64 { { 0, 0 }, { 1, 1 } } ) ; err == nil	{
+		t.Error ( "Not enough points but there is no error" )
+	}
+
+	if	_, err = Akima_path ( & [][] float64 {} ) ; err == nil	{
+		t.Error ( "No points but there is no error" )
+	}
+
+	if	_, err = Akima_path ( & [][] float64 { {}, { 1, 1 }, { 2, 2 }, { 3, 3 }, { 4, 4 } } ) ; err == nil	{
+		t.Error ( "Point has no coordinates but there is no error" )
+	}
+}
@@ -0,0 +1,165 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "sort" ; "github.com/sjbog/math_tools" )
+
+//	Shape of a cubic curve, see Bezier_cubic_analysis
+type Cubic_type	int
+
+const	(
+//	Control points are collinear ( or equal )
+	Cubic_line	Cubic_type	= iota
+//	Degree elevated quadratic curve
+	Cubic_quadratic
+//	Curve has inflection points ( S shape )
+	Cubic_serpentine
+//	Curve crosses itself, its double point might lie out of 0.0 <= offset <= 1.0
+	Cubic_loop
+//	Derivative is zero at some offset, which might lie out of 0.0 <= offset <= 1.0
+	Cubic_cusp
+)
+
+/*	Result of Bezier_cubic_analysis
+
+	Inflections			: offsets of inflection points within [ 0, 1 ], ascending
+	Cusp				: offset of the cusp ( Cubic_cusp only ), might be out of [ 0, 1 ]
+	Self_intersection	: two offsets of the double point ( Cubic_loop ), nil if either lies out of [ 0, 1 ]
+*/
+type Cubic_analysis struct {
+
+	Type	Cubic_type
+
+	Inflections			[] float64
+	Cusp				float64
+	Self_intersection	[] float64
+}
+
+
+/*	Classifies a 2-D cubic Bézier curve and finds its inflection points, cusp and self-intersection
+
+	The cubic case of Bezier_point in power form :
+
+		B( t ) = a t^3 + b t^2 + c t + P0
+
+		a = -P0 + 3 P1 - 3 P2 + P3
+		b = 3 P0 - 6 P1 + 3 P2
+		c = 3 ( P1 - P0 )
+
+	Inflection points are roots of B'( t ) × B''( t ) / 2 :
+
+		-3 ( a × b ) t^2  +  3 ( c × a ) t  +  c × b
+
+	Its discriminant tells the shape ( Stone and DeRose, "A geometric characterization of parametric cubic curves", 1989 ) :
+	two real roots - serpentine, a double root - cusp ( B' is zero there ), complex roots - loop.
+
+	The double point B( t1 ) == B( t2 ), t1 != t2, after dividing by ( t1 - t2 ) :
+
+		a ( t1^2 + t1 t2 + t2^2 ) + b ( t1 + t2 ) + c = 0
+
+	so s = t1 + t2 = ( a × c ) / ( b × a ), p = t1 * t2 = s^2 + ( b s + c ) / a, and t1, t2 are roots of t^2 - s t + p.
+
+	Returns an error if the curve is not a 2-D cubic ( 4 control points )
+*/
+func Bezier_cubic_analysis ( control_points  * [][] float64 )		( result  Cubic_analysis, err  error )	{
+
+	if	len ( * control_points ) != 4	|| len ( ( * control_points ) [ 0 ] ) != 2	{
+
+		return	result, math_tools.Arg_range_error ()
+	}
+
+	var (
+		p0, p1, p2, p3	= ( * control_points ) [ 0 ], ( * control_points ) [ 1 ], ( * control_points ) [ 2 ], ( * control_points ) [ 3 ]
+
+		a	= [ 2 ] float64 { -p0 [ 0 ] + 3 * p1 [ 0 ] - 3 * p2 [ 0 ] + p3 [ 0 ], -p0 [ 1 ] + 3 * p1 [ 1 ] - 3 * p2 [ 1 ] + p3 [ 1 ] }
+		b	= [ 2 ] float64 { 3 * p0 [ 0 ] - 6 * p1 [ 0 ] + 3 * p2 [ 0 ], 3 * p0 [ 1 ] - 6 * p1 [ 1 ] + 3 * p2 [ 1 ] }
+		c	= [ 2 ] float64 { 3 * ( p1 [ 0 ] - p0 [ 0 ] ), 3 * ( p1 [ 1 ] - p0 [ 1 ] ) }
+
+		cross	= func ( u, v  [ 2 ] float64 )	float64	{	return	u [ 0 ] * v [ 1 ] - u [ 1 ] * v [ 0 ]	}
+
+//		Size of the curve for relative comparisons
+		scale	= math.Max ( math.Max ( math.Hypot ( a [ 0 ], a [ 1 ] ), math.Hypot ( b [ 0 ], b [ 1 ] ) ), math.Hypot ( c [ 0 ], c [ 1 ] ) )
+		epsilon	= 1e-9 * scale * scale
+
+		ab, ca, cb	= cross ( a, b ), cross ( c, a ), cross ( c, b )
+	)
+
+	if	math.Abs ( ab ) <= epsilon	&& math.Abs ( ca ) <= epsilon	&& math.Abs ( cb ) <= epsilon	{
+
+//		a, b and c are parallel, so are the control points
+		result.Type	= Cubic_line
+		return
+	}
+
+	var (
+		quadratic, linear, constant	= -3 * ab, 3 * ca, cb
+		discriminant	= linear * linear - 4 * quadratic * constant
+
+//		Discriminant is of the squared magnitude of the coefficients, so is its rounding error
+		discriminant_epsilon	= 1e-9 * ( quadratic * quadratic + linear * linear + constant * constant )
+	)
+
+	switch	{
+
+		case math.Abs ( quadratic ) <= epsilon :
+
+//			One inflection is at infinity
+			result.Type	= Cubic_serpentine
+
+			if	math.Abs ( linear ) <= epsilon	{
+				result.Type	= Cubic_quadratic
+			} else	{
+				result.Inflections	= offsets_in_range ( -constant / linear )
+			}
+
+		case math.Abs ( discriminant ) <= discriminant_epsilon :
+
+			result.Type	= Cubic_cusp
+			result.Cusp	= -linear / ( 2 * quadratic )
+
+		case discriminant > 0 :
+
+			var root	= math.Sqrt ( discriminant )
+
+			result.Type	= Cubic_serpentine
+			result.Inflections	= offsets_in_range (
+				( -linear - root ) / ( 2 * quadratic ),
+				( -linear + root ) / ( 2 * quadratic ),
+			)
+
+		default :
+
+			result.Type	= Cubic_loop
+
+			var (
+				sum	= cross ( a, c ) / cross ( b, a )
+				product	float64
+			)
+
+			if	math.Abs ( a [ 0 ] ) >= math.Abs ( a [ 1 ] )	{
+				product	= sum * sum + ( b [ 0 ] * sum + c [ 0 ] ) / a [ 0 ]
+			} else	{
+				product	= sum * sum + ( b [ 1 ] * sum + c [ 1 ] ) / a [ 1 ]
+			}
+
+			if	root := sum * sum - 4 * product ; root > 0	{
+
+				var t1, t2	= ( sum - math.Sqrt ( root ) ) / 2.0, ( sum + math.Sqrt ( root ) ) / 2.0
+
+				if	t1 >= 0 && t2 <= 1	{	result.Self_intersection	= [] float64 { t1, t2 }	}
+			}
+	}
+	return
+}
+
+//	Offsets within [ 0, 1 ], ascending
+func offsets_in_range ( offsets  ... float64 )		( result  [] float64 )	{
+
+	for	_, offset := range	offsets	{
+//		-0.0 is stored as 0.0
+		if	offset == 0	{	offset	= 0	}
+
+		if	offset >= 0	&& offset <= 1	{	result	= append ( result, offset )	}
+	}
+	sort.Float64s ( result )
+	return
+}
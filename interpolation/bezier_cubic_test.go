@@ -0,0 +1,73 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"testing"
+)
+
+
+func Test_Bezier_cubic_analysis ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [...] struct	{
+		points		[][] float64
+		expected	Cubic_type
+		inflections	string
+		cusp		float64
+		loop		bool
+	}	{
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0, 1.0 }, { 3.0, 3.0 }, { 2.0, 2.0 } },	Cubic_line,	"[]",	0.0,	false	},
+		{	[][] float64 { { 1.0, 1.0 }, { 1.0, 1.0 }, { 1.0, 1.0 }, { 1.0, 1.0 } },	Cubic_line,	"[]",	0.0,	false	},
+		{	Bezier_elevate_degree ( & [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } }, 1 ),	Cubic_quadratic,	"[]",	0.0,	false	},
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, -2.0 }, { 3.0, 0.0 } },	Cubic_serpentine,	"[0.5000]",	0.0,	false	},
+//		y = x^3
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0 / 3.0, 0.0 }, { 2.0 / 3.0, 0.0 }, { 1.0, 1.0 } },	Cubic_serpentine,	"[0.0000]",	0.0,	false	},
+		{	[][] float64 { { 0.0, 0.0 }, { 4.0, 4.0 }, { 0.0, 4.0 }, { 4.0, 0.0 } },	Cubic_cusp,	"[]",	0.5,	false	},
+//		Same cusp translated, the coordinates are not exact in binary
+		{	[][] float64 { { 0.1, 0.2 }, { 4.1, 4.2 }, { 0.1, 4.2 }, { 4.1, 0.2 } },	Cubic_cusp,	"[]",	0.5,	false	},
+		{	[][] float64 { { 0.0, 0.0 }, { 6.0, 4.0 }, { -2.0, 4.0 }, { 4.0, 0.0 } },	Cubic_loop,	"[]",	0.0,	true	},
+//		Arch has no inflections, its loop is out of range
+		{	[][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },	Cubic_loop,	"[]",	0.0,	false	},
+	}
+
+	for	ci, c := range	cases	{
+
+		var result, err	= Bezier_cubic_analysis ( & c.points )
+
+		if	err != nil	|| result.Type != c.expected	|| fmt.Sprintf ( "%.4f", result.Inflections ) != c.inflections	||
+			fmt.Sprintf ( "%.4f", result.Cusp ) != fmt.Sprintf ( "%.4f", c.cusp )	|| ( result.Self_intersection != nil ) != c.loop	{
+
+			t.Errorf ( "Case %d : expected %v %v %v %v, got : %+v ( %v )", ci, c.expected, c.inflections, c.cusp, c.loop, result, err )
+			continue
+		}
+
+		if	c.loop	{
+
+			var (
+				first	= Bezier_point ( & c.points, result.Self_intersection [ 0 ] )
+				second	= Bezier_point ( & c.points, result.Self_intersection [ 1 ] )
+			)
+
+			if	points_distance ( first, second ) > 1e-9	{
+				t.Errorf ( "Case %d : self intersection %v points differ %v %v", ci, result.Self_intersection, first, second )
+			}
+		}
+
+		if	c.expected == Cubic_cusp	{
+
+			var derivative	= Bezier_derivative ( & c.points )
+
+			if	speed := vector_length ( Bezier_point ( & derivative, result.Cusp ) ) ; speed > 1e-9	{
+				t.Errorf ( "Case %d : derivative at the cusp is not zero : %v", ci, speed )
+			}
+		}
+	}
+
+	if	_, err := Bezier_cubic_analysis ( & [][] float64 { { 0.0, 0.0 }, { 1.0, 1.0 }, { 2.0, 0.0 } } ) ; err == nil	{
+		t.Error ( "Curve is not cubic but there is no error" )
+	}
+}
@@ -0,0 +1,210 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+/*	Degree elevation : the same curve described by more control points
+
+	A curve of degree n is elevated by r degrees exactly :
+
+		Q_i = Σ C( n, j ) * C( r, i - j ) / C( n + r, i ) * P_j	, where max ( 0, i - r ) <= j <= min ( n, i )
+*/
+func Bezier_elevate_degree ( control_points  * [][] float64, times  uint )		( result  [][] float64 )	{
+
+	var points_len	= uint ( len ( * control_points ) )
+
+	if	points_len == 0	{	return	result	}
+
+	var (
+		degree		= points_len -1
+		dimensions	= len ( ( * control_points ) [ 0 ] )
+		weight		float64
+	)
+	result	= make ( [][] float64, points_len + times )
+
+	for	i := range	result	{
+
+		result [ i ]	= make ( [] float64, dimensions )
+
+		for	j := uint ( 0 ) ; j <= degree && j <= uint ( i ) ; j ++	{
+
+			if	uint ( i ) - j > times	{	continue	}
+
+			weight	= binomial_float ( degree, j ) *
+				binomial_float ( times, uint ( i ) - j ) /
+				binomial_float ( degree + times, uint ( i ) )
+
+			for	di := 0 ; di < dimensions ; di ++	{
+				result [ i ][ di ]	+= weight * ( * control_points ) [ j ][ di ]
+			}
+		}
+	}
+	return
+}
+
+
+/*	Least-squares degree reduction : a curve of a lower degree, closest to the given one
+
+	End points are preserved, inner control points Q minimise the integral over 0.0 <= t <= 1.0 of
+
+		| B_n( t ) - Q_m( t ) |^2
+
+	Bernstein polynomials products integrate to :
+
+		∫ B_i,m * B_j,n dt = C( m, i ) * C( n, j ) / ( ( m + n + 1 ) * C( m + n, i + j ) )
+
+	Return
+
+		result		: control points of the reduced curve
+		error_bound	: maximal distance between the curves is not bigger, see below
+		err			: curve is empty or degree is not in 1 <= degree <= current degree
+
+	The error bound is the maximal distance between control points of the original curve and the reduced one, elevated back to degree n :
+	difference of the curves is a Bézier curve, which lies in the convex hull of its control points.
+*/
+func Bezier_reduce_degree ( control_points  * [][] float64, degree  uint )		( result  [][] float64, error_bound  float64, err  error )	{
+
+	var points_len	= uint ( len ( * control_points ) )
+
+	if	points_len == 0	|| degree == 0	|| degree >= points_len	{
+
+		return	result, error_bound, math_tools.Arg_range_error ()
+	}
+
+	var (
+		n, m		= points_len -1, degree
+		dimensions	= len ( ( * control_points ) [ 0 ] )
+		first, last	= ( * control_points ) [ 0 ], ( * control_points ) [ n ]
+
+		integral	= func ( i, m, j, n  uint )	float64	{
+			return	binomial_float ( m, i ) *
+				binomial_float ( n, j ) /
+				( float64 ( m + n +1 ) * binomial_float ( m + n, i + j ) )
+		}
+	)
+	result	= make ( [][] float64, m +1 )
+	result [ 0 ], result [ m ]	= append ( [] float64 ( nil ), first... ), append ( [] float64 ( nil ), last... )
+
+	if	m > 1	{
+
+//		Normal equations for the inner control points 1 .. m -1
+		var (
+			gram	= make ( [][] float64, m -1 )
+			rhs		= make ( [][] float64, m -1 )
+		)
+
+		for	i := uint ( 1 ) ; i < m ; i ++	{
+
+			gram [ i -1 ]	= make ( [] float64, m -1 )
+			rhs [ i -1 ]	= make ( [] float64, dimensions )
+
+			for	j := uint ( 1 ) ; j < m ; j ++	{
+				gram [ i -1 ][ j -1 ]	= integral ( i, m, j, m )
+			}
+
+			for	j := uint ( 0 ) ; j <= n ; j ++	{
+
+				var weight	= integral ( i, m, j, n )
+
+				for	di := 0 ; di < dimensions ; di ++	{
+					rhs [ i -1 ][ di ]	+= weight * ( * control_points ) [ j ][ di ]
+				}
+			}
+
+			for	di := 0 ; di < dimensions ; di ++	{
+				rhs [ i -1 ][ di ]	-= integral ( i, m, 0, m ) * first [ di ]  +  integral ( i, m, m, m ) * last [ di ]
+			}
+		}
+
+		var inner, ok	= solve_linear ( gram, rhs )
+
+		if	! ok	{	return	nil, error_bound, math_tools.Arg_range_error ()	}
+
+		copy ( result [ 1 : m ], inner )
+	}
+
+	var elevated	= Bezier_elevate_degree ( & result, n - m )
+
+	for	i := range	elevated	{
+		error_bound	= math.Max ( error_bound, points_distance ( elevated [ i ], ( * control_points ) [ i ] ) )
+	}
+	return
+}
+
+
+/*	Reduces the degree of a curve, splitting it in halves until every part is within tolerance
+
+	For example cubic curves are converted to quadratic ones ( TrueType ) :
+
+		Bezier_reduce_degree_within ( & cubic, 2, 0.01 )
+
+	Parts are returned in order, the end of each part is the start of the next one.
+	Splitting stops after 16 levels, err is set if a part still exceeds tolerance.
+*/
+func Bezier_reduce_degree_within ( control_points  * [][] float64, degree  uint, tolerance  float64 )		( result  [][][] float64, err  error )	{
+
+	if	! ( tolerance > 0 )	{	return	result, math_tools.Arg_range_error ()	}
+
+	return	bezier_reduce_within ( control_points, degree, tolerance, 0, result )
+}
+
+func bezier_reduce_within ( control_points  * [][] float64, degree  uint, tolerance  float64, depth  int, result  [][][] float64 )		( [][][] float64, error )	{
+
+	var reduced, error_bound, err	= Bezier_reduce_degree ( control_points, degree )
+
+	if	err != nil	{	return	result, err	}
+
+	if	error_bound <= tolerance	{	return	append ( result, reduced ), nil	}
+
+	if	depth >= 16	{	return	append ( result, reduced ), math_tools.Arg_range_error ()	}
+
+	var left, right	= Bezier_split ( control_points, 0.5 )
+
+	if	result, err	= bezier_reduce_within ( & left, degree, tolerance, depth +1, result ) ; err != nil	{
+		return	result, err
+	}
+	return	bezier_reduce_within ( & right, degree, tolerance, depth +1, result )
+}
+
+
+/*	Solves a linear system with several right hand sides by Gaussian elimination with partial pivoting
+
+	Arguments are modified, returns false if the matrix is singular
+*/
+func solve_linear ( matrix, rhs  [][] float64 )		( [][] float64, bool )	{
+
+	var size	= len ( matrix )
+
+	for	column := 0 ; column < size ; column ++	{
+
+		var pivot	= column
+
+		for	row := column +1 ; row < size ; row ++	{
+			if	math.Abs ( matrix [ row ][ column ] ) > math.Abs ( matrix [ pivot ][ column ] )	{	pivot	= row	}
+		}
+
+		if	matrix [ pivot ][ column ] == 0	{	return	nil, false	}
+
+		matrix [ column ], matrix [ pivot ]	= matrix [ pivot ], matrix [ column ]
+		rhs [ column ], rhs [ pivot ]		= rhs [ pivot ], rhs [ column ]
+
+		for	row := column +1 ; row < size ; row ++	{
+
+			var factor	= matrix [ row ][ column ] / matrix [ column ][ column ]
+
+			for	k := column ; k < size ; k ++	{	matrix [ row ][ k ]	-= factor * matrix [ column ][ k ]	}
+			for	k := range	rhs [ row ]	{	rhs [ row ][ k ]	-= factor * rhs [ column ][ k ]	}
+		}
+	}
+
+	for	row := size -1 ; row >= 0 ; row --	{
+		for	k := range	rhs [ row ]	{
+
+			for	column := row +1 ; column < size ; column ++	{
+				rhs [ row ][ k ]	-= matrix [ row ][ column ] * rhs [ column ][ k ]
+			}
+			rhs [ row ][ k ]	/= matrix [ row ][ row ]
+		}
+	}
+	return	rhs, true
+}
@@ -0,0 +1,138 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"testing"
+)
+
+
+func Test_Bezier_elevate_degree ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		quadratic	= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } }
+		expected	= [][] float64 { { 0.0, 0.0 }, { 2.0 / 3.0, 4.0 / 3.0 }, { 4.0 / 3.0, 4.0 / 3.0 }, { 2.0, 0.0 } }
+	)
+
+	for	times := uint ( 0 ) ; times < 5 ; times ++	{
+
+		var result	= Bezier_elevate_degree ( & quadratic, times )
+
+		if	len ( result ) != len ( quadratic ) + int ( times )	{
+			t.Errorf ( "Expected %d points, got : %v", len ( quadratic ) + int ( times ), result )
+			t.FailNow ()
+		}
+
+		if	times == 1	&& fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", expected )	{
+			t.Errorf ( "Expected = %v, got : %v", expected, result )
+		}
+
+		for	i := 0 ; i <= 10 ; i ++	{
+
+			var offset	= float64 ( i ) / 10.0
+
+			if	fmt.Sprintf ( "%.6f", Bezier_point ( & quadratic, offset ) ) != fmt.Sprintf ( "%.6f", Bezier_point ( & result, offset ) )	{
+
+				t.Errorf ( "Elevated by %d at %.2f : expected %v, got : %v",
+					times, offset, Bezier_point ( & quadratic, offset ), Bezier_point ( & result, offset ),
+				)
+				t.FailNow ()
+			}
+		}
+	}
+}
+
+func Test_Bezier_reduce_degree ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		quadratic	= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } }
+		quartic		= Bezier_elevate_degree ( & quadratic, 2 )
+		cubic		= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, -2.0 }, { 3.0, 0.0 } }
+	)
+
+//	Elevated curve is reduced back exactly
+	var result, error_bound, err	= Bezier_reduce_degree ( & quartic, 2 )
+
+	if	err != nil	|| error_bound > 1e-9	|| fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", quadratic )	{
+		t.Errorf ( "Expected = %v, got : %v, error bound %v ( %v )", quadratic, result, error_bound, err )
+	}
+
+//	Error bound is not smaller than the real distance
+	for	degree := uint ( 1 ) ; degree < 3 ; degree ++	{
+
+		result, error_bound, err	= Bezier_reduce_degree ( & cubic, degree )
+
+		if	err != nil	|| len ( result ) != int ( degree ) +1	{
+			t.Error ( "Reduced curve ", result, err )
+			t.FailNow ()
+		}
+
+		for	i := 0 ; i <= 100 ; i ++	{
+
+			var distance	= points_distance (
+				Bezier_point ( & cubic, float64 ( i ) / 100.0 ),
+				Bezier_point ( & result, float64 ( i ) / 100.0 ),
+			)
+
+			if	distance > error_bound	{
+				t.Errorf ( "Degree %d : distance %v is bigger than error bound %v", degree, distance, error_bound )
+				t.FailNow ()
+			}
+		}
+	}
+
+	if	_, _, err = Bezier_reduce_degree ( & cubic, 4 ) ; err == nil	{
+		t.Error ( "Degree is higher but there is no error" )
+	}
+
+	if	_, _, err = Bezier_reduce_degree ( & cubic, 0 ) ; err == nil	{
+		t.Error ( "Degree is zero but there is no error" )
+	}
+}
+
+func Test_Bezier_reduce_degree_within ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		cubic		= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, -2.0 }, { 3.0, 0.0 } }
+		tolerance	= 0.001
+	)
+
+	var result, err	= Bezier_reduce_degree_within ( & cubic, 2, tolerance )
+
+	if	err != nil	|| len ( result ) < 2	{
+		t.Error ( "Quadratic parts ", result, err )
+		t.FailNow ()
+	}
+
+	for	i, part := range	result	{
+
+		if	len ( part ) != 3	{
+			t.Errorf ( "Part %d is not quadratic : %v", i, part )
+		}
+
+		if	i > 0	&& fmt.Sprint ( result [ i -1 ][ 2 ] ) != fmt.Sprint ( part [ 0 ] )	{
+			t.Errorf ( "Part %d doesn't start at the end of the previous one : %v %v", i, result [ i -1 ], part )
+		}
+
+		for	step := 0 ; step <= 20 ; step ++	{
+
+			var (
+				point	= Bezier_point ( & part, float64 ( step ) / 20.0 )
+				_, _, distance, _	= Bezier_closest_point ( & cubic, point )
+			)
+
+			if	distance > tolerance	{
+				t.Errorf ( "Part %d at %v is %v away from the cubic", i, point, distance )
+				t.FailNow ()
+			}
+		}
+	}
+}
@@ -0,0 +1,251 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+/*	PHILIP J. SCHNEIDER "An algorithm for automatically fitting digitized curves" ( Graphics Gems, 1990 )
+
+	Fits a piecewise cubic Bézier path to the sampled points :
+
+	1) Points are parametrised by the chord length, end tangents are estimated from the neighbour points.
+
+	2) Inner control points lie on the end tangents : P1 = P0 + α1 * t1, P2 = P3 + α2 * t2,
+	α1 and α2 minimise the sum of squared distances between the samples and the curve at their parameters ( least squares ).
+
+	3) If the maximal distance exceeds tolerance, parameters are improved by Newton iterations on the closest point condition
+
+		u = u - ( Q( u ) - P ) · Q'( u )  /  ( Q'( u ) · Q'( u )  +  ( Q( u ) - P ) · Q''( u ) )
+
+	and the curve is fitted again, while the distance decreases ( up to 20 times ).
+
+	4) Otherwise the points are split at the worst fitted one and both parts are fitted recursively,
+	so the path stays smooth at the split ( tangent there is common ).
+
+	Return
+
+		curves	: control points of cubic curves ( see Bezier_point ), the end of each is the start of the next one,
+				  all points are new ( they don't share memory with the input or each other )
+		err		: less than 2 distinct points, dimensions differ or tolerance is not positive
+*/
+func Bezier_fit_cubic ( points  [][] float64, tolerance  float64 )		( curves  [][][] float64, err  error )	{
+
+	if	len ( points ) == 0	|| ! ( tolerance > 0 )	{	return	curves, math_tools.Arg_range_error ()	}
+
+	var (
+		dimensions	= len ( points [ 0 ] )
+		distinct	= [][] float64 { points [ 0 ] }
+	)
+
+	for	_, point := range	points [ 1 : ]	{
+
+		if	len ( point ) != dimensions	{	return	curves, math_tools.Arg_range_error ()	}
+
+		if	points_distance ( point, distinct [ len ( distinct ) -1 ] ) > 0	{
+			distinct	= append ( distinct, point )
+		}
+	}
+
+	var last	= len ( distinct ) -1
+
+	if	last < 1	{	return	curves, math_tools.Arg_range_error ()	}
+
+	return	bezier_fit_cubic (
+		distinct,
+		unit_vector ( distinct [ 0 ], distinct [ 1 ] ),
+		unit_vector ( distinct [ last ], distinct [ last -1 ] ),
+		tolerance, curves,
+	), err
+}
+
+func bezier_fit_cubic ( points  [][] float64, tangent1, tangent2  [] float64, tolerance  float64, curves  [][][] float64 )		[][][] float64	{
+
+	var last	= len ( points ) -1
+
+	if	last == 1	{
+
+		var distance	= points_distance ( points [ 0 ], points [ 1 ] ) / 3.0
+
+		return	append ( curves, [][] float64 {
+			append ( [] float64 ( nil ), points [ 0 ]... ),
+			add_scaled ( points [ 0 ], tangent1, distance ),
+			add_scaled ( points [ 1 ], tangent2, distance ),
+			append ( [] float64 ( nil ), points [ 1 ]... ),
+		})
+	}
+
+	var (
+		parameters	= chord_length_parameters ( points )
+		curve		= bezier_fit_tangents ( points, parameters, tangent1, tangent2 )
+
+		max_error, split	= bezier_fit_error ( points, & curve, parameters )
+	)
+
+	for	iteration := 0 ; max_error > tolerance && iteration < 20 ; iteration ++	{
+
+		var (
+			next_parameters	= bezier_reparametrise ( points, & curve, parameters )
+			next_curve		= bezier_fit_tangents ( points, next_parameters, tangent1, tangent2 )
+
+			next_error, next_split	= bezier_fit_error ( points, & next_curve, next_parameters )
+		)
+
+		if	next_error >= max_error	{	break	}
+
+		parameters, curve, max_error, split	= next_parameters, next_curve, next_error, next_split
+	}
+
+	if	max_error <= tolerance	{	return	append ( curves, curve )	}
+
+	var center	= unit_vector ( points [ split +1 ], points [ split -1 ] )
+
+	if	center == nil	{	center	= unit_vector ( points [ split ], points [ split -1 ] )	}
+
+	curves	= bezier_fit_cubic ( points [ : split +1 ], tangent1, center, tolerance, curves )
+
+	for	di := range	center	{	center [ di ]	= -center [ di ]	}
+
+	return	bezier_fit_cubic ( points [ split : ], center, tangent2, tolerance, curves )
+}
+
+
+/*	Least squares lengths of the end tangents ( see step 2 )
+
+	Falls back to Wu / Barsky heuristic ( a third of the chord ) if the solution is degenerate
+*/
+func bezier_fit_tangents ( points  [][] float64, parameters  [] float64, tangent1, tangent2  [] float64 )		[][] float64	{
+
+	var (
+		first, last	= points [ 0 ], points [ len ( points ) -1 ]
+
+		c00, c01, c11, x0, x1	float64
+	)
+
+	for	i, point := range	points	{
+
+		var (
+			u	= parameters [ i ]
+			b0, b1, b2, b3	= Bernstein_basis ( 3, 0, u ), Bernstein_basis ( 3, 1, u ), Bernstein_basis ( 3, 2, u ), Bernstein_basis ( 3, 3, u )
+		)
+
+		for	di := range	point	{
+
+			var (
+				a0, a1	= tangent1 [ di ] * b1, tangent2 [ di ] * b2
+				rest	= point [ di ] - first [ di ] * ( b0 + b1 ) - last [ di ] * ( b2 + b3 )
+			)
+			c00, c01, c11	= c00 + a0 * a0, c01 + a0 * a1, c11 + a1 * a1
+			x0, x1	= x0 + a0 * rest, x1 + a1 * rest
+		}
+	}
+
+	var (
+		det		= c00 * c11 - c01 * c01
+		alpha1, alpha2	float64
+
+		chord	= points_distance ( first, last )
+	)
+
+	if	det != 0	{
+		alpha1	= ( x0 * c11 - x1 * c01 ) / det
+		alpha2	= ( c00 * x1 - c01 * x0 ) / det
+	}
+
+	if	alpha1 < 1e-6 * chord	|| alpha2 < 1e-6 * chord	{
+		alpha1, alpha2	= chord / 3.0, chord / 3.0
+	}
+
+	return	[][] float64 {
+		append ( [] float64 ( nil ), first... ),
+		add_scaled ( first, tangent1, alpha1 ),
+		add_scaled ( last, tangent2, alpha2 ),
+		append ( [] float64 ( nil ), last... ),
+	}
+}
+
+//	Maximal distance between the points and the curve at their parameters, inner index of the worst point
+func bezier_fit_error ( points  [][] float64, curve  * [][] float64, parameters  [] float64 )		( max_error  float64, split  int )	{
+
+	split	= len ( points ) / 2
+
+	for	i := 1 ; i < len ( points ) -1 ; i ++	{
+
+		if	distance := points_distance ( Bezier_point ( curve, parameters [ i ] ), points [ i ] ) ; distance > max_error	{
+			max_error, split	= distance, i
+		}
+	}
+	return
+}
+
+//	Newton iteration for every parameter ( see step 3 )
+func bezier_reparametrise ( points  [][] float64, curve  * [][] float64, parameters  [] float64 )		( result  [] float64 )	{
+
+	var (
+		first	= Bezier_derivative ( curve )
+		second	= Bezier_derivative ( & first )
+	)
+	result	= make ( [] float64, len ( parameters ) )
+
+	for	i, u := range	parameters	{
+
+		var (
+			point	= Bezier_point ( curve, u )
+			d1, d2	= Bezier_point ( & first, u ), Bezier_point ( & second, u )
+
+			numerator, denominator	float64
+		)
+
+		for	di := range	point	{
+
+			var difference	= point [ di ] - points [ i ][ di ]
+
+			numerator	+= difference * d1 [ di ]
+			denominator	+= d1 [ di ] * d1 [ di ]  +  difference * d2 [ di ]
+		}
+
+		result [ i ]	= u
+
+		if	denominator != 0	{
+			result [ i ]	= math.Max ( 0, math.Min ( 1, u - numerator / denominator ) )
+		}
+	}
+	return
+}
+
+func chord_length_parameters ( points  [][] float64 )		( parameters  [] float64 )	{
+
+	parameters	= make ( [] float64, len ( points ) )
+
+	for	i := 1 ; i < len ( points ) ; i ++	{
+		parameters [ i ]	= parameters [ i -1 ] + points_distance ( points [ i ], points [ i -1 ] )
+	}
+
+	for	i, total := 1, parameters [ len ( points ) -1 ] ; i < len ( points ) ; i ++	{
+		parameters [ i ]	/= total
+	}
+	return
+}
+
+//	Unit vector from a to b, nil if points are equal
+func unit_vector ( a, b  [] float64 )		( result  [] float64 )	{
+
+	var length	= points_distance ( a, b )
+
+	if	length == 0	{	return	nil	}
+
+	result	= make ( [] float64, len ( a ) )
+
+	for	di := range	a	{
+		result [ di ]	= ( b [ di ] - a [ di ] ) / length
+	}
+	return
+}
+
+func add_scaled ( point, vector  [] float64, scale  float64 )		( result  [] float64 )	{
+
+	result	= make ( [] float64, len ( point ) )
+
+	for	di := range	point	{
+		result [ di ]	= point [ di ] + vector [ di ] * scale
+	}
+	return
+}
@@ -0,0 +1,114 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+func Test_Bezier_fit_cubic ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		cubic	= [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } }
+
+		sampled_cubic, noisy_sin, circle	[][] float64
+	)
+
+	for	i := 0 ; i <= 50 ; i ++	{
+		sampled_cubic	= append ( sampled_cubic, Bezier_point ( & cubic, float64 ( i ) / 50.0 ) )
+	}
+
+	for	i := 0 ; i <= 200 ; i ++	{
+
+		var x	= float64 ( i ) * 4 * math.Pi / 200.0
+
+//		Deterministic "noise" of amplitude 0.01
+		noisy_sin	= append ( noisy_sin, [] float64 { x, math.Sin ( x ) + 0.01 * math.Sin ( float64 ( i * i ) ) } )
+	}
+
+	for	i := 0 ; i <= 90 ; i ++	{
+
+		var angle	= float64 ( i ) * 2 * math.Pi / 90.0
+
+		circle	= append ( circle, [] float64 { math.Cos ( angle ), math.Sin ( angle ), 0.5 } )
+	}
+
+	var cases	= [...] struct	{
+		points		[][] float64
+		tolerance	float64
+		max_curves	int
+	}	{
+		{	sampled_cubic, 0.1, 1	},
+		{	noisy_sin, 0.05, 12	},
+		{	circle, 1e-3, 8	},
+		{	[][] float64 { { 0.0, 0.0 }, { 0.0, 0.0 }, { 3.0, 4.0 } }, 1e-3, 1	},
+	}
+
+	for	ci, c := range	cases	{
+
+		var curves, err	= Bezier_fit_cubic ( c.points, c.tolerance )
+
+		if	err != nil	|| len ( curves ) == 0	|| len ( curves ) > c.max_curves	{
+			t.Errorf ( "Case %d : expected up to %d curves, got : %d ( %v )", ci, c.max_curves, len ( curves ), err )
+			continue
+		}
+
+		if	fmt.Sprint ( curves [ 0 ][ 0 ] ) != fmt.Sprint ( c.points [ 0 ] )	||
+			fmt.Sprint ( curves [ len ( curves ) -1 ][ 3 ] ) != fmt.Sprint ( c.points [ len ( c.points ) -1 ] )	{
+
+			t.Errorf ( "Case %d : path doesn't connect the end points : %v", ci, curves )
+		}
+
+		for	i, curve := range	curves	{
+
+			if	len ( curve ) != 4	{	t.Errorf ( "Case %d : curve %d is not cubic %v", ci, i, curve )	}
+
+			if	i > 0	&& fmt.Sprint ( curves [ i -1 ][ 3 ] ) != fmt.Sprint ( curve [ 0 ] )	{
+				t.Errorf ( "Case %d : curve %d doesn't start at the end of the previous one", ci, i )
+			}
+		}
+
+		for	_, point := range	c.points	{
+
+			var closest	= math.Inf ( 1 )
+
+			for	ii := range	curves	{
+				_, _, distance, _	:= Bezier_closest_point ( & curves [ ii ], point )
+				closest	= math.Min ( closest, distance )
+			}
+
+			if	closest > c.tolerance	{
+				t.Errorf ( "Case %d : point %v is %v away from the path", ci, point, closest )
+				break
+			}
+		}
+	}
+
+//	Curves don't share points with the input or each other
+	var (
+		points		= [][] float64 { { 0.0, 0.0 }, { 3.0, 4.0 } }
+		curves, _	= Bezier_fit_cubic ( points, 0.1 )
+		noisy, _	= Bezier_fit_cubic ( noisy_sin, 0.05 )
+	)
+
+	curves [ 0 ][ 0 ][ 0 ], curves [ 0 ][ 3 ][ 0 ]	= 7.0, 7.0
+	noisy [ 0 ][ 3 ][ 0 ]	= 100.0
+
+	if	points [ 0 ][ 0 ] != 0.0	|| points [ 1 ][ 0 ] != 3.0	|| len ( noisy ) < 2	|| noisy [ 1 ][ 0 ][ 0 ] == 100.0	{
+		t.Errorf ( "Changing the result modified the input or the next curve : %v, %v", points, noisy [ : min ( 2, len ( noisy ) ) ] )
+	}
+
+	if	_, err := Bezier_fit_cubic ( [][] float64 { { 1.0, 1.0 }, { 1.0, 1.0 } }, 0.1 ) ; err == nil	{
+		t.Error ( "Points are equal but there is no error" )
+	}
+
+	if	_, err := Bezier_fit_cubic ( sampled_cubic, 0.0 ) ; err == nil	{
+		t.Error ( "Tolerance is not positive but there is no error" )
+	}
+}
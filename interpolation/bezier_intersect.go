@@ -0,0 +1,575 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "sort" ; "github.com/sjbog/math_tools" )
+
+/*	Intersection of two curves ( or of a curve and a line )
+
+	Offset1	: offset on the first curve
+	Offset2	: offset on the second curve, or a line parameter : point = origin + Offset2 * direction
+
+	Tangent	: curves touch at this point ( tangents are parallel within sqrt ( tolerance ) ), the contact is reported once
+	Overlap	: curves share a common part, which lies between two consecutive intersections with Overlap set
+*/
+type Bezier_intersection struct {
+
+	Offset1, Offset2	float64
+	Tangent, Overlap	bool
+}
+
+//	Part of a curve, which is subdivided : offsets of its ends on the original curve
+type bezier_piece struct {
+
+	points		[][] float64
+	start, end	float64
+}
+
+
+/*	Finds all intersections of two 2-D Bézier curves
+
+	Curves are recursively subdivided, pairs of parts with disjoint bounding boxes are rejected.
+	When both parts are flat ( control points are within tolerance from the chord ) they are intersected as line segments
+	and the result is refined by Newton iterations on both curves.
+
+	Intersections closer than tolerance, between which curves do not separate more than tolerance, are one contact and reported once.
+	If a part of one curve coincides with a part of the other, only the ends of the common part are reported.
+
+	Return
+
+		result	: intersections sorted by Offset1
+		err		: curves have less than 2 control points or are not 2-D, tolerance is not positive
+*/
+func Bezier_intersections ( curve1, curve2  * [][] float64, tolerance  float64 )		( result  [] Bezier_intersection, err  error )	{
+
+	if	len ( * curve1 ) < 2	|| len ( ( * curve1 ) [ 0 ] ) != 2	||
+		len ( * curve2 ) < 2	|| len ( ( * curve2 ) [ 0 ] ) != 2	||
+		! ( tolerance > 0 )	{
+
+		return	result, math_tools.Arg_range_error ()
+	}
+
+	if	result	= bezier_overlap ( curve1, curve2, tolerance ) ; result != nil	{	return	}
+
+	result	= bezier_pieces_intersections (
+		bezier_piece { * curve1, 0.0, 1.0 },
+		bezier_piece { * curve2, 0.0, 1.0 },
+		tolerance, 0, result,
+	)
+
+	var (
+		derivative1	= Bezier_derivative ( curve1 )
+		derivative2	= Bezier_derivative ( curve2 )
+	)
+
+	for	i := range	result	{
+
+		result [ i ].Offset1, result [ i ].Offset2	= bezier_newton_intersection (
+			curve1, curve2, & derivative1, & derivative2,
+			result [ i ].Offset1, result [ i ].Offset2,
+		)
+		result [ i ].Tangent	= tangents_parallel (
+			Bezier_point ( & derivative1, result [ i ].Offset1 ),
+			Bezier_point ( & derivative2, result [ i ].Offset2 ),
+			tolerance,
+		)
+	}
+
+	return	merge_intersections ( result, func ( offset1, offset2  float64 )	float64	{
+		return	points_distance ( Bezier_point ( curve1, offset1 ), Bezier_point ( curve2, offset2 ) )
+	}, tolerance ), err
+}
+
+
+/*	Finds all intersections of a 2-D Bézier curve and a line : origin + s * direction
+
+	Signed distances of control points from the line are coefficients of a Bernstein polynomial,
+	its roots are isolated by subdivision. Extrema of the polynomial that lie within tolerance from the line are tangential contacts.
+	If the whole curve lies within tolerance from the line, its end points are reported with Overlap set.
+
+	Offset2 of the result is the line parameter s ( in units of direction length )
+*/
+func Bezier_line_intersections ( control_points  * [][] float64, origin, direction  [] float64, tolerance  float64 )		( result  [] Bezier_intersection, err  error )	{
+
+	if	len ( * control_points ) == 0	|| len ( ( * control_points ) [ 0 ] ) != 2	||
+		len ( origin ) != 2	|| len ( direction ) != 2	||
+		( direction [ 0 ] == 0 && direction [ 1 ] == 0 )	|| ! ( tolerance > 0 )	{
+
+		return	result, math_tools.Arg_range_error ()
+	}
+
+	var (
+		length	= math.Hypot ( direction [ 0 ], direction [ 1 ] )
+//		Unit normal of the line
+		nx, ny	= -direction [ 1 ] / length, direction [ 0 ] / length
+
+		signed_distance	= make ( [][] float64, len ( * control_points ) )
+		overlap		= true
+		offsets		[] float64
+	)
+
+	for	i, point := range	* control_points	{
+
+		signed_distance [ i ]	= [] float64 { nx * ( point [ 0 ] - origin [ 0 ] ) + ny * ( point [ 1 ] - origin [ 1 ] ) }
+		overlap	= overlap && math.Abs ( signed_distance [ i ][ 0 ] ) <= tolerance
+	}
+
+	var line_offset	= func ( offset  float64 )	float64	{
+
+		var point	= Bezier_point ( control_points, offset )
+
+		return	( direction [ 0 ] * ( point [ 0 ] - origin [ 0 ] ) + direction [ 1 ] * ( point [ 1 ] - origin [ 1 ] ) ) / ( length * length )
+	}
+
+	if	overlap	{
+
+		result	= append ( result, Bezier_intersection { 0.0, line_offset ( 0.0 ), false, true } )
+
+		if	len ( * control_points ) > 1	{
+			result	= append ( result, Bezier_intersection { 1.0, line_offset ( 1.0 ), false, true } )
+		}
+		return
+	}
+
+	offsets	= bernstein_roots ( signed_distance, 0.0, 1.0, 0, offsets )
+
+//	Touching points : extrema close to the line and curve ends on the line
+	var distance_derivative	= Bezier_derivative ( & signed_distance )
+
+	for	_, offset := range	append ( bernstein_roots ( distance_derivative, 0.0, 1.0, 0, nil ), 0.0, 1.0 )	{
+
+		if	math.Abs ( Bezier_point ( & signed_distance, offset ) [ 0 ] ) <= tolerance	{
+			offsets	= append ( offsets, offset )
+		}
+	}
+
+	var derivative	= Bezier_derivative ( control_points )
+
+	for	_, offset := range	offsets	{
+
+		result	= append ( result, Bezier_intersection {
+			Offset1	: offset,
+			Offset2	: line_offset ( offset ),
+			Tangent	: tangents_parallel ( Bezier_point ( & derivative, offset ), direction, tolerance ),
+		})
+	}
+
+	return	merge_intersections ( result, func ( offset1, _  float64 )	float64	{
+		return	math.Abs ( Bezier_point ( & signed_distance, offset1 ) [ 0 ] )
+	}, tolerance ), err
+}
+
+
+/*	Same as Bezier_line_intersections, but only the intersections with the ray ( s >= 0 ) are returned
+
+	An overlapping part, which starts behind the origin, is cut at the origin
+*/
+func Bezier_ray_intersections ( control_points  * [][] float64, origin, direction  [] float64, tolerance  float64 )		( result  [] Bezier_intersection, err  error )	{
+
+	var line	[] Bezier_intersection
+
+	if	line, err	= Bezier_line_intersections ( control_points, origin, direction, tolerance ) ; err != nil	{
+		return
+	}
+
+	var margin	= tolerance / math.Hypot ( direction [ 0 ], direction [ 1 ] )
+
+	for	i := 0 ; i < len ( line ) ; i ++	{
+
+		if	! line [ i ].Overlap	|| i +1 == len ( line )	{
+
+			if	line [ i ].Offset2 >= -margin	{	result	= append ( result, line [ i ] )	}
+			continue
+		}
+
+		var start, end	= line [ i ], line [ i +1 ]
+		i ++
+
+		if	start.Offset2 < -margin	&& end.Offset2 < -margin	{	continue	}
+
+		if	start.Offset2 < -margin	|| end.Offset2 < -margin	{
+
+//			Curve offset, where it crosses the origin : a root of s( t )
+			var projection	= make ( [][] float64, len ( * control_points ) )
+
+			for	pi, point := range	* control_points	{
+				projection [ pi ]	= [] float64 { direction [ 0 ] * ( point [ 0 ] - origin [ 0 ] ) + direction [ 1 ] * ( point [ 1 ] - origin [ 1 ] ) }
+			}
+
+			var cut	= Bezier_intersection { Offset2 : 0.0, Overlap : true }
+
+			if	roots := bernstein_roots ( projection, 0.0, 1.0, 0, nil ) ; len ( roots ) > 0	{
+				cut.Offset1	= roots [ 0 ]
+			}
+
+			if	start.Offset2 < -margin	{	start	= cut	} else	{	end	= cut	}
+		}
+		result	= append ( result, start, end )
+	}
+	return
+}
+
+
+func bezier_pieces_intersections ( a, b  bezier_piece, tolerance  float64, depth  int, result  [] Bezier_intersection )		[] Bezier_intersection	{
+
+	var (
+		a_min, a_max	= bounding_box ( a.points )
+		b_min, b_max	= bounding_box ( b.points )
+	)
+
+	for	di := range	a_min	{
+
+		if	a_min [ di ] > b_max [ di ] + tolerance	|| b_min [ di ] > a_max [ di ] + tolerance	{
+			return	result
+		}
+	}
+
+	var (
+		a_flat	= polygon_flatness ( a.points ) <= tolerance
+		b_flat	= polygon_flatness ( b.points ) <= tolerance
+	)
+
+	if	a_flat && b_flat	|| depth >= 48	{
+
+		return	segments_intersection ( a, b, tolerance, result )
+	}
+
+//	Split the part, which is not flat and bigger
+	if	! a_flat	&& ( b_flat	|| points_distance ( a_min, a_max ) >= points_distance ( b_min, b_max ) )	{
+
+		var left, right	= a.split ()
+
+		result	= bezier_pieces_intersections ( left, b, tolerance, depth +1, result )
+		return	bezier_pieces_intersections ( right, b, tolerance, depth +1, result )
+	}
+
+	var left, right	= b.split ()
+
+	result	= bezier_pieces_intersections ( a, left, tolerance, depth +1, result )
+	return	bezier_pieces_intersections ( a, right, tolerance, depth +1, result )
+}
+
+func ( self  bezier_piece )	split ()		( left, right  bezier_piece )	{
+
+	var middle	= ( self.start + self.end ) / 2.0
+
+	left.points, right.points	= Bezier_split ( & self.points, 0.5 )
+	left.start, left.end	= self.start, middle
+	right.start, right.end	= middle, self.end
+	return
+}
+
+func ( self  bezier_piece )	offset ( local  float64 )		float64	{
+	return	self.start + local * ( self.end - self.start )
+}
+
+
+/*	Intersects chords of two flat pieces
+
+	Parallel chords within tolerance from each other touch in the middle of their common projection
+*/
+func segments_intersection ( a, b  bezier_piece, tolerance  float64, result  [] Bezier_intersection )		[] Bezier_intersection	{
+
+	var (
+		p, p_end	= a.points [ 0 ], a.points [ len ( a.points ) -1 ]
+		q, q_end	= b.points [ 0 ], b.points [ len ( b.points ) -1 ]
+
+		rx, ry	= p_end [ 0 ] - p [ 0 ], p_end [ 1 ] - p [ 1 ]
+		sx, sy	= q_end [ 0 ] - q [ 0 ], q_end [ 1 ] - q [ 1 ]
+		qx, qy	= q [ 0 ] - p [ 0 ], q [ 1 ] - p [ 1 ]
+
+		rr, ss	= rx * rx + ry * ry, sx * sx + sy * sy
+		cross	= rx * sy - ry * sx
+	)
+
+	if	math.Abs ( cross ) > 1e-12 * math.Sqrt ( rr * ss )	{
+
+		var (
+			u	= ( qx * sy - qy * sx ) / cross
+			v	= ( qx * ry - qy * rx ) / cross
+
+			u_margin	= tolerance / math.Sqrt ( rr )
+			v_margin	= tolerance / math.Sqrt ( ss )
+		)
+
+		if	u < -u_margin	|| u > 1 + u_margin	|| v < -v_margin	|| v > 1 + v_margin	{
+			return	result
+		}
+
+		return	append ( result, Bezier_intersection {
+			Offset1	: a.offset ( math.Max ( 0, math.Min ( 1, u ) ) ),
+			Offset2	: b.offset ( math.Max ( 0, math.Min ( 1, v ) ) ),
+		})
+	}
+
+//	Parallel or degenerate chords, the longer one is the base
+	var swapped	= rr < ss
+
+	if	swapped	{
+		a, b	= b, a
+		p, q	= q, p
+		rx, ry, sx, sy	= sx, sy, rx, ry
+		qx, qy	= -qx, -qy
+		rr, ss	= ss, rr
+	}
+
+	if	rr == 0	{
+
+		if	math.Hypot ( qx, qy ) > tolerance	{	return	result	}
+
+		return	append ( result, Bezier_intersection { a.offset ( 0.5 ), b.offset ( 0.5 ), true, false } )
+	}
+
+	if	math.Abs ( qx * ry - qy * rx ) / math.Sqrt ( rr ) > tolerance	{	return	result	}
+
+	var (
+		u0	= ( qx * rx + qy * ry ) / rr
+		u1	= ( ( qx + sx ) * rx + ( qy + sy ) * ry ) / rr
+
+		low		= math.Max ( 0, math.Min ( u0, u1 ) )
+		high	= math.Min ( 1, math.Max ( u0, u1 ) )
+	)
+
+	if	low > high + tolerance / math.Sqrt ( rr )	{	return	result	}
+
+	var (
+		u	= math.Max ( 0, math.Min ( 1, ( low + high ) / 2.0 ) )
+		v	= 0.5
+	)
+
+	if	ss > 0	{
+		v	= math.Max ( 0, math.Min ( 1, ( ( u * rx - qx ) * sx + ( u * ry - qy ) * sy ) / ss ) )
+	}
+
+	var intersection	= Bezier_intersection { a.offset ( u ), b.offset ( v ), true, false }
+
+	if	swapped	{
+		intersection.Offset1, intersection.Offset2	= intersection.Offset2, intersection.Offset1
+	}
+	return	append ( result, intersection )
+}
+
+
+/*	Refines an intersection by Newton iterations on B1( t1 ) - B2( t2 ) = 0
+
+	Offsets are returned unchanged if the Jacobian is singular ( tangential contact ) or the distance is not reduced
+*/
+func bezier_newton_intersection ( curve1, curve2, derivative1, derivative2  * [][] float64, offset1, offset2  float64 )		( float64, float64 )	{
+
+	if	len ( * derivative1 ) == 0	|| len ( * derivative2 ) == 0	{	return	offset1, offset2	}
+
+	var (
+		t1, t2		= offset1, offset2
+		initial		= points_distance ( Bezier_point ( curve1, t1 ), Bezier_point ( curve2, t2 ) )
+	)
+
+	for	iteration := 0 ; iteration < 16 ; iteration ++	{
+
+		var (
+			p1, p2	= Bezier_point ( curve1, t1 ), Bezier_point ( curve2, t2 )
+			d1, d2	= Bezier_point ( derivative1, t1 ), Bezier_point ( derivative2, t2 )
+
+			fx, fy	= p1 [ 0 ] - p2 [ 0 ], p1 [ 1 ] - p2 [ 1 ]
+			det		= d2 [ 0 ] * d1 [ 1 ] - d1 [ 0 ] * d2 [ 1 ]
+		)
+
+		if	math.Abs ( det ) <= 1e-12 * math.Hypot ( d1 [ 0 ], d1 [ 1 ] ) * math.Hypot ( d2 [ 0 ], d2 [ 1 ] )	{
+			break
+		}
+
+		var (
+			dt1	= ( d2 [ 1 ] * fx - d2 [ 0 ] * fy ) / det
+			dt2	= ( d1 [ 1 ] * fx - d1 [ 0 ] * fy ) / det
+		)
+		t1	= math.Max ( 0, math.Min ( 1, t1 + dt1 ) )
+		t2	= math.Max ( 0, math.Min ( 1, t2 + dt2 ) )
+
+		if	math.Abs ( dt1 ) + math.Abs ( dt2 ) < 1e-15	{	break	}
+	}
+
+	if	points_distance ( Bezier_point ( curve1, t1 ), Bezier_point ( curve2, t2 ) ) <= initial	{
+		return	t1, t2
+	}
+	return	offset1, offset2
+}
+
+
+/*	Finds a common part of two curves
+
+	End points of each curve are projected onto the other, if exactly two distinct pairs of offsets are found,
+	the curves are cut to these offsets and compared : the curve of the lower degree is elevated ( see Bezier_elevate_degree ),
+	so equally parametrised parts have the same control points. Otherwise the parts may still trace the same points
+	at different speeds ( e.g. a line and a collinear quadratic ), so samples of each part are projected onto the other one.
+*/
+func bezier_overlap ( curve1, curve2  * [][] float64, tolerance  float64 )		( result  [] Bezier_intersection )	{
+
+	if	len ( * curve1 ) < 2	|| len ( * curve2 ) < 2	{	return	nil	}
+
+	switch	{
+		case	len ( * curve1 ) < len ( * curve2 )	:
+			var elevated	= Bezier_elevate_degree ( curve1, uint ( len ( * curve2 ) - len ( * curve1 ) ) )
+			curve1	= & elevated
+
+		case	len ( * curve2 ) < len ( * curve1 )	:
+			var elevated	= Bezier_elevate_degree ( curve2, uint ( len ( * curve1 ) - len ( * curve2 ) ) )
+			curve2	= & elevated
+	}
+
+	var pairs	[] Bezier_intersection
+
+	var add_pair	= func ( offset1, offset2  float64 )	{
+
+		for	_, pair := range	pairs	{
+			if	math.Abs ( pair.Offset1 - offset1 ) < 1e-9	&& math.Abs ( pair.Offset2 - offset2 ) < 1e-9	{	return	}
+		}
+		pairs	= append ( pairs, Bezier_intersection { offset1, offset2, false, true } )
+	}
+
+	for	_, end := range	[] float64 { 0.0, 1.0 }	{
+
+		if	offset, _, distance, _ := Bezier_closest_point ( curve2, Bezier_point ( curve1, end ) ) ; distance <= tolerance	{
+			add_pair ( end, offset )
+		}
+		if	offset, _, distance, _ := Bezier_closest_point ( curve1, Bezier_point ( curve2, end ) ) ; distance <= tolerance	{
+			add_pair ( offset, end )
+		}
+	}
+
+	if	len ( pairs ) != 2	{	return	nil	}
+
+	sort.Slice ( pairs, func ( i, j  int )	bool	{	return	pairs [ i ].Offset1 < pairs [ j ].Offset1	})
+
+	var (
+		part1	= bezier_segment ( curve1, pairs [ 0 ].Offset1, pairs [ 1 ].Offset1 )
+		part2	= bezier_segment ( curve2, pairs [ 0 ].Offset2, pairs [ 1 ].Offset2 )
+	)
+
+	if	pairs [ 1 ].Offset1 - pairs [ 0 ].Offset1 < 1e-9	{	return	nil	}
+
+	for	i := range	part1	{
+		if	points_distance ( part1 [ i ], part2 [ i ] ) > tolerance	{
+
+			if	bezier_parts_coincide ( & part1, & part2, tolerance )	{	return	pairs	}
+			return	nil
+		}
+	}
+	return	pairs
+}
+
+//	Samples of each part lie within tolerance from the other part
+func bezier_parts_coincide ( part1, part2  * [][] float64, tolerance  float64 )		bool	{
+
+	var samples	= 8 * len ( * part1 )
+
+	for	_, parts := range	[ 2 ][ 2 ] * [][] float64 { { part1, part2 }, { part2, part1 } }	{
+		for	i := 1 ; i < samples ; i ++	{
+
+			var _, _, distance, _	= Bezier_closest_point ( parts [ 1 ], Bezier_point ( parts [ 0 ], float64 ( i ) / float64 ( samples ) ) )
+
+			if	distance > tolerance	{	return	false	}
+		}
+	}
+	return	true
+}
+
+/*	Control points of the curve part between two offsets, reversed if end < start
+*/
+func bezier_segment ( control_points  * [][] float64, start, end  float64 )		( result  [][] float64 )	{
+
+	var reversed	= end < start
+
+	if	reversed	{	start, end	= end, start	}
+
+	result, _	= Bezier_split ( control_points, end )
+
+	if	end > 0	{
+		_, result	= Bezier_split ( & result, start / end )
+	}
+
+	if	reversed	{
+		for	i, j := 0, len ( result ) -1 ; i < j ; i, j = i +1, j -1	{
+			result [ i ], result [ j ]	= result [ j ], result [ i ]
+		}
+	}
+	return
+}
+
+
+/*	Sorts intersections by Offset1 and merges the neighbours of one contact :
+	curves do not separate more than tolerance between them ( distance is measured in the middle ).
+	The intersection with the smallest distance represents the contact.
+*/
+func merge_intersections ( intersections  [] Bezier_intersection, distance  func ( offset1, offset2  float64 ) float64, tolerance  float64 )		( result  [] Bezier_intersection )	{
+
+	sort.Slice ( intersections, func ( i, j  int )	bool	{
+		return	intersections [ i ].Offset1 < intersections [ j ].Offset1
+	})
+
+	for	_, intersection := range	intersections	{
+
+		var last	= len ( result ) -1
+
+		if	last < 0	|| distance (
+				( result [ last ].Offset1 + intersection.Offset1 ) / 2.0,
+				( result [ last ].Offset2 + intersection.Offset2 ) / 2.0,
+			) > tolerance	{
+
+			result	= append ( result, intersection )
+			continue
+		}
+
+		intersection.Tangent	= intersection.Tangent || result [ last ].Tangent
+
+		if	distance ( intersection.Offset1, intersection.Offset2 ) < distance ( result [ last ].Offset1, result [ last ].Offset2 )	{
+			result [ last ]	= intersection
+		} else	{
+			result [ last ].Tangent	= intersection.Tangent
+		}
+	}
+	return
+}
+
+
+func tangents_parallel ( a, b  [] float64, tolerance  float64 )		bool	{
+
+	var length	= math.Hypot ( a [ 0 ], a [ 1 ] ) * math.Hypot ( b [ 0 ], b [ 1 ] )
+
+	return	length == 0	|| math.Abs ( a [ 0 ] * b [ 1 ] - a [ 1 ] * b [ 0 ] ) <= math.Sqrt ( tolerance ) * length
+}
+
+func bounding_box ( points  [][] float64 )		( box_min, box_max  [] float64 )	{
+
+	box_min	= append ( [] float64 ( nil ), points [ 0 ]... )
+	box_max	= append ( [] float64 ( nil ), points [ 0 ]... )
+
+	for	_, point := range	points [ 1 : ]	{
+		for	di, value := range	point	{
+
+			box_min [ di ]	= math.Min ( box_min [ di ], value )
+			box_max [ di ]	= math.Max ( box_max [ di ], value )
+		}
+	}
+	return
+}
+
+//	Maximal distance of 2-D control points from the chord, 0 for less than 3 points
+func polygon_flatness ( points  [][] float64 )		( flatness  float64 )	{
+
+	if	len ( points ) < 3	{	return	0	}
+
+	var (
+		first, last	= points [ 0 ], points [ len ( points ) -1 ]
+
+		dx, dy	= last [ 0 ] - first [ 0 ], last [ 1 ] - first [ 1 ]
+		length	= math.Hypot ( dx, dy )
+	)
+
+	for	_, point := range	points [ 1 : len ( points ) -1 ]	{
+
+		if	length == 0	{
+			flatness	= math.Max ( flatness, points_distance ( point, first ) )
+			continue
+		}
+		flatness	= math.Max ( flatness, math.Abs ( ( point [ 0 ] - first [ 0 ] ) * dy - ( point [ 1 ] - first [ 1 ] ) * dx ) / length )
+	}
+	return
+}
@@ -0,0 +1,217 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"testing"
+)
+
+
+func Test_Bezier_intersections ( t * testing.T )	{
+
+	t.Parallel ()
+
+	type Intersection_test struct	{
+		curve1, curve2	[][] float64
+		expected	[] Bezier_intersection
+	}
+
+	var (
+		s_curve	= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, -2.0 }, { 3.0, 0.0 } }
+
+		cases	= [...] Intersection_test {
+
+//			Crossing lines
+			Intersection_test {
+				curve1	: [][] float64 { { 0.0, 0.0 }, { 2.0, 2.0 } },
+				curve2	: [][] float64 { { 0.0, 2.0 }, { 2.0, 0.0 } },
+				expected	: [] Bezier_intersection { { 0.5, 0.5, false, false } },
+			},
+
+//			y = 6t( 1 - t )( 1 - 2t ), x = 3t crosses the x axis at t = 0, 0.5, 1
+			Intersection_test {
+				curve1	: s_curve,
+				curve2	: [][] float64 { { 0.0, 0.0 }, { 3.0, 0.0 } },
+				expected	: [] Bezier_intersection {
+					{ 0.0, 0.0, false, false },
+					{ 0.5, 0.5, false, false },
+					{ 1.0, 1.0, false, false },
+				},
+			},
+
+//			Parabola touches the line y = 1 at its top
+			Intersection_test {
+				curve1	: [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } },
+				curve2	: [][] float64 { { 0.0, 1.0 }, { 2.0, 1.0 } },
+				expected	: [] Bezier_intersection { { 0.5, 0.5, true, false } },
+			},
+
+//			Second curve is a part of the first one
+			Intersection_test {
+				curve1	: s_curve,
+				curve2	: bezier_segment ( & s_curve, 0.25, 0.75 ),
+				expected	: [] Bezier_intersection {
+					{ 0.25, 0.0, false, true },
+					{ 0.75, 1.0, false, true },
+				},
+			},
+
+//			Same, but the part is reversed
+			Intersection_test {
+				curve1	: s_curve,
+				curve2	: bezier_segment ( & s_curve, 0.75, 0.25 ),
+				expected	: [] Bezier_intersection {
+					{ 0.25, 1.0, false, true },
+					{ 0.75, 0.0, false, true },
+				},
+			},
+
+//			Disjoint curves
+			Intersection_test {
+				curve1	: s_curve,
+				curve2	: [][] float64 { { 0.0, 3.0 }, { 1.0, 4.0 }, { 3.0, 3.0 } },
+				expected	: nil,
+			},
+
+//			Line and a collinear quadratic of the same speed overlap at [ 0.25, 0.75 ] of the quadratic
+			Intersection_test {
+				curve1	: [][] float64 { { 1.0, 0.0 }, { 3.0, 0.0 } },
+				curve2	: [][] float64 { { 0.0, 0.0 }, { 2.0, 0.0 }, { 4.0, 0.0 } },
+				expected	: [] Bezier_intersection {
+					{ 0.0, 0.25, false, true },
+					{ 1.0, 0.75, false, true },
+				},
+			},
+
+//			Collinear quadratic of a varying speed : x = 1 + 2t + 2t², it reaches x = 4 at t = 0.8229
+			Intersection_test {
+				curve1	: [][] float64 { { 0.0, 0.0 }, { 4.0, 0.0 } },
+				curve2	: [][] float64 { { 1.0, 0.0 }, { 2.0, 0.0 }, { 5.0, 0.0 } },
+				expected	: [] Bezier_intersection {
+					{ 0.25, 0.0, false, true },
+					{ 1.0, 0.8229, false, true },
+				},
+			},
+
+//			Two cubics, which cross twice
+			Intersection_test {
+				curve1	: [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },
+				curve2	: [][] float64 { { 0.0, 4.0 }, { 8.0, 0.0 }, { 16.0, 4.0 } },
+				expected	: [] Bezier_intersection {
+					{ 0.1721, 0.0787, false, false },
+					{ 0.8279, 0.9213, false, false },
+				},
+			},
+		}
+		tolerance	= 1e-9
+	)
+
+	for	ci := range	cases	{
+
+		var result, err	= Bezier_intersections ( & cases [ ci ].curve1, & cases [ ci ].curve2, tolerance )
+
+		if	err != nil	|| format_intersections ( result ) != format_intersections ( cases [ ci ].expected )	{
+
+			t.Errorf ( "Case %d : expected %v, got : %v ( %v )",
+				ci, format_intersections ( cases [ ci ].expected ), format_intersections ( result ), err,
+			)
+			continue
+		}
+
+		for	_, intersection := range	result	{
+
+			var distance	= points_distance (
+				Bezier_point ( & cases [ ci ].curve1, intersection.Offset1 ),
+				Bezier_point ( & cases [ ci ].curve2, intersection.Offset2 ),
+			)
+
+			if	distance > 1e-6	{
+				t.Errorf ( "Case %d : intersection %v points are %v apart", ci, intersection, distance )
+			}
+		}
+	}
+
+	if	_, err := Bezier_intersections ( & s_curve, & [][] float64 { { 0.0, 0.0, 0.0 } }, tolerance ) ; err == nil	{
+		t.Error ( "Arguments are wrong but there is no error" )
+	}
+
+	if	_, err := Bezier_intersections ( & [][] float64 { { 0.0, 0.0 } }, & s_curve, tolerance ) ; err == nil	{
+		t.Error ( "Curve has a single point but there is no error" )
+	}
+
+	if	_, err := Bezier_intersections ( & s_curve, & s_curve, 0.0 ) ; err == nil	{
+		t.Error ( "Tolerance is not positive but there is no error" )
+	}
+}
+
+func Test_Bezier_line_intersections ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		s_curve		= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, -2.0 }, { 3.0, 0.0 } }
+		parabola	= [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } }
+		straight	= [][] float64 { { 1.0, 1.0 }, { 2.0, 2.0 }, { 3.0, 3.0 } }
+		tolerance	= 1e-9
+
+		cases	= [...] struct	{
+			points	* [][] float64
+			origin, direction	[] float64
+			ray		bool
+
+			expected	[] Bezier_intersection
+		}	{
+			{	& s_curve, [] float64 { 0.0, 0.0 }, [] float64 { 1.0, 0.0 }, false,
+				[] Bezier_intersection { { 0.0, 0.0, false, false }, { 0.5, 1.5, false, false }, { 1.0, 3.0, false, false } },
+			},
+			{	& s_curve, [] float64 { 1.0, 0.0 }, [] float64 { 2.0, 0.0 }, true,
+				[] Bezier_intersection { { 0.5, 0.25, false, false }, { 1.0, 1.0, false, false } },
+			},
+			{	& parabola, [] float64 { 0.0, 1.0 }, [] float64 { 1.0, 0.0 }, false,
+				[] Bezier_intersection { { 0.5, 1.0, true, false } },
+			},
+			{	& parabola, [] float64 { 0.0, 2.0 }, [] float64 { 1.0, 0.0 }, false,
+				nil,
+			},
+			{	& straight, [] float64 { 0.0, 0.0 }, [] float64 { 1.0, 1.0 }, false,
+				[] Bezier_intersection { { 0.0, 1.0, false, true }, { 1.0, 3.0, false, true } },
+			},
+			{	& straight, [] float64 { 2.0, 2.0 }, [] float64 { 1.0, 1.0 }, true,
+				[] Bezier_intersection { { 0.5, 0.0, false, true }, { 1.0, 1.0, false, true } },
+			},
+		}
+
+		result	[] Bezier_intersection
+		err		error
+	)
+
+	for	ci, c := range	cases	{
+
+		if	c.ray	{
+			result, err	= Bezier_ray_intersections ( c.points, c.origin, c.direction, tolerance )
+		} else	{
+			result, err	= Bezier_line_intersections ( c.points, c.origin, c.direction, tolerance )
+		}
+
+		if	err != nil	|| format_intersections ( result ) != format_intersections ( c.expected )	{
+
+			t.Errorf ( "Case %d : expected %v, got : %v ( %v )",
+				ci, format_intersections ( c.expected ), format_intersections ( result ), err,
+			)
+		}
+	}
+
+	if	_, err = Bezier_line_intersections ( & s_curve, [] float64 { 0.0, 0.0 }, [] float64 { 0.0, 0.0 }, tolerance ) ; err == nil	{
+		t.Error ( "Direction is zero but there is no error" )
+	}
+}
+
+func format_intersections ( intersections  [] Bezier_intersection )		( result  string )	{
+
+	for	_, i := range	intersections	{
+		result	+= fmt.Sprintf ( "( %.4f %.4f %v %v ) ", i.Offset1, i.Offset2, i.Tangent, i.Overlap )
+	}
+	return
+}
@@ -0,0 +1,221 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+/*	Approximates the offset ( parallel ) curve of a 2-D Bézier curve by cubic curves
+
+	Every point of the offset curve lies at the distance along the curve normal :
+
+		O( t ) = B( t ) + distance * N( t )	, where N = ( -B'y, B'x ) / | B' | is the unit normal to the left
+
+	Negative distance offsets to the right side.
+
+	1) The curve is split at inflection points and cusps : roots of B'( t ) × B''( t ).
+
+	2) Each part is approximated by a cubic, which starts and ends at the offset points and keeps the curve tangents there,
+	lengths of its handles are fitted to sampled offset points by least squares ( see Bezier_fit_cubic ).
+
+	3) The distance is checked both ways ( Hausdorff distance ) : offset points between the samples must lie within tolerance from the cubic
+	and points of the cubic within tolerance from the offset curve, so the cubic neither skips a part of the offset nor strays from it.
+	Otherwise the part is split in halves ( up to 10 times ).
+
+	At cusps the normal flips, so neighbour parts of the result are not connected there.
+
+	Return
+
+		curves	: control points of cubic curves in the curve direction
+		err		: curve is empty or not 2-D, tolerance is not positive
+*/
+func Bezier_offset ( control_points  * [][] float64, distance, tolerance  float64 )		( curves  [][][] float64, err  error )	{
+
+	if	len ( * control_points ) == 0	|| len ( ( * control_points ) [ 0 ] ) != 2	|| ! ( tolerance > 0 )	{
+
+		return	curves, math_tools.Arg_range_error ()
+	}
+
+	if	len ( * control_points ) == 1	{	return	curves, nil	}
+
+	var (
+		first	= Bezier_derivative ( control_points )
+		second	= Bezier_derivative ( & first )
+		splits	= [] float64 { 0.0 }
+	)
+
+	if	len ( second ) > 0	{
+
+		var curvature_sign	= bernstein_product ( first, second, func ( a, b  [] float64 )	float64	{
+			return	a [ 0 ] * b [ 1 ] - a [ 1 ] * b [ 0 ]
+		})
+
+		for	_, root := range	bernstein_roots ( curvature_sign, 0.0, 1.0, 0, nil )	{
+
+			if	root > 1e-9	&& root < 1 - 1e-9	{	splits	= append ( splits, root )	}
+		}
+	}
+	splits	= append ( splits, 1.0 )
+
+	for	i := 1 ; i < len ( splits ) ; i ++	{
+
+		var part	= bezier_segment ( control_points, splits [ i -1 ], splits [ i ] )
+
+		curves	= bezier_offset_part ( & part, distance, tolerance, 0, curves )
+	}
+	return
+}
+
+func bezier_offset_part ( control_points  * [][] float64, distance, tolerance  float64, depth  int, curves  [][][] float64 )		[][][] float64	{
+
+	const	samples	= 16
+
+	var (
+		start_tangent	= control_polygon_direction ( * control_points, true )
+		end_tangent		= control_polygon_direction ( * control_points, false )
+	)
+
+	if	start_tangent == nil	{	return	curves	}
+
+	var (
+		derivative	= Bezier_derivative ( control_points )
+		points		= make ( [][] float64, samples +1 )
+	)
+
+	for	i := range	points	{
+		points [ i ]	= offset_point ( control_points, & derivative, float64 ( i ) / samples, distance )
+	}
+
+//	Normals at the ends are taken from the control polygon, it is defined at cusps too
+	points [ 0 ]	= add_scaled ( ( * control_points ) [ 0 ], [] float64 { -start_tangent [ 1 ], start_tangent [ 0 ] }, distance )
+	points [ samples ]	= add_scaled ( ( * control_points ) [ len ( * control_points ) -1 ], [] float64 { -end_tangent [ 1 ], end_tangent [ 0 ] }, distance )
+
+	var curve	= bezier_fit_tangents (
+		points, chord_length_parameters ( points ),
+		start_tangent, [] float64 { -end_tangent [ 0 ], -end_tangent [ 1 ] },
+	)
+
+	if	depth >= 10	|| bezier_offset_fits ( control_points, & derivative, & curve, points, distance, tolerance )	{
+		return	append ( curves, curve )
+	}
+
+	var left, right	= Bezier_split ( control_points, 0.5 )
+
+	curves	= bezier_offset_part ( & left, distance, tolerance, depth +1, curves )
+	return	bezier_offset_part ( & right, distance, tolerance, depth +1, curves )
+}
+
+//	Offset points are within tolerance from the cubic and points of the cubic are within tolerance from the offset curve ( see step 3 )
+func bezier_offset_fits ( control_points, derivative, curve  * [][] float64, points  [][] float64, distance, tolerance  float64 )		bool	{
+
+	var samples	= 2 * ( len ( points ) -1 )
+
+	for	i := 0 ; i < samples ; i ++	{
+
+		var offset	= ( float64 ( i ) + 0.5 ) / float64 ( samples )
+
+		if	check := offset_point ( control_points, derivative, offset, distance ) ; check != nil	{
+
+			if	_, _, error_distance, _ := Bezier_closest_point ( curve, check ) ; error_distance > tolerance	{	return	false	}
+		}
+
+		if	offset_curve_distance ( control_points, derivative, points, Bezier_point ( curve, offset ), distance ) > tolerance	{
+			return	false
+		}
+	}
+	return	true
+}
+
+/*	Distance from the query to the offset curve
+
+	Distances to the offset points ( sampled at i / ( len ( points ) -1 ), nil where the normal is not defined ) are refined
+	by the golden section search around each local minimum : the offset curve folds where the curvature radius is less than the distance,
+	so the nearest sample may lie on the other branch and the offset point of the closest curve point may be a wrong one
+*/
+func offset_curve_distance ( control_points, derivative  * [][] float64, points  [][] float64, query  [] float64, distance  float64 )		( result  float64 )	{
+
+	var (
+		last		= len ( points ) -1
+		distances	= make ( [] float64, len ( points ) )
+
+		measure	= func ( offset  float64 )	float64	{
+
+			if	point := offset_point ( control_points, derivative, offset, distance ) ; point != nil	{
+				return	points_distance ( point, query )
+			}
+			return	math.Inf ( 1 )
+		}
+	)
+	result	= math.Inf ( 1 )
+
+	for	i, point := range	points	{
+
+		distances [ i ]	= math.Inf ( 1 )
+
+		if	point != nil	{
+			distances [ i ]	= points_distance ( point, query )
+			result	= math.Min ( result, distances [ i ] )
+		}
+	}
+
+	for	i := range	points	{
+
+		if	points [ i ] == nil	|| i > 0 && distances [ i -1 ] < distances [ i ]	|| i < last && distances [ i +1 ] < distances [ i ]	{
+			continue
+		}
+
+		var (
+			low		= float64 ( max ( i -1, 0 ) ) / float64 ( last )
+			high	= float64 ( min ( i +1, last ) ) / float64 ( last )
+			ratio	= ( math.Sqrt ( 5 ) -1 ) / 2
+		)
+
+		for	iteration := 0 ; iteration < 40 ; iteration ++	{
+
+			var left, right	= high - ratio * ( high - low ), low + ratio * ( high - low )
+
+			if	measure ( left ) < measure ( right )	{
+				high	= right
+			} else	{
+				low		= left
+			}
+		}
+		result	= math.Min ( result, measure ( ( low + high ) / 2 ) )
+	}
+	return
+}
+
+/*	Offset point computed directly : B( t ) + distance * N( t )
+
+	Returns nil if the derivative is zero ( normal is not defined )
+*/
+func offset_point ( control_points, derivative  * [][] float64, offset, distance  float64 )		[] float64	{
+
+	var (
+		point	= Bezier_point ( control_points, offset )
+		d		= Bezier_point ( derivative, offset )
+		length	= math.Hypot ( d [ 0 ], d [ 1 ] )
+	)
+
+	if	length == 0	{	return	nil	}
+
+	return	[] float64 { point [ 0 ] - distance * d [ 1 ] / length, point [ 1 ] + distance * d [ 0 ] / length }
+}
+
+/*	Unit tangent at the start ( or end ) from the first distinct control points, nil if all points are equal
+
+	Unlike B'( 0 ) it is defined when the first control points coincide
+*/
+func control_polygon_direction ( points  [][] float64, start  bool )		[] float64	{
+
+	var last	= len ( points ) -1
+
+	for	i := 1 ; i <= last ; i ++	{
+
+		if	start	{
+			if	direction := unit_vector ( points [ 0 ], points [ i ] ) ; direction != nil	{	return	direction	}
+
+		} else	if	direction := unit_vector ( points [ last - i ], points [ last ] ) ; direction != nil	{
+			return	direction
+		}
+	}
+	return	nil
+}
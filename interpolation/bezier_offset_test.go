@@ -0,0 +1,105 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+func Test_Bezier_offset ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [...] struct	{
+		points		[][] float64
+		distance	float64
+		min_curves	int
+	}	{
+//		Line
+		{	[][] float64 { { 0.0, 0.0 }, { 4.0, 0.0 } },	1.0,	1	},
+//		Arch, both sides
+		{	[][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },	1.0,	1	},
+		{	[][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },	-2.0,	1	},
+//		S-curve has an inflection at t = 0.5
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, -2.0 }, { 3.0, 0.0 } },	0.25,	2	},
+//		Cusp at t = 0.5
+		{	[][] float64 { { 0.0, 0.0 }, { 4.0, 4.0 }, { 0.0, 4.0 }, { 4.0, 0.0 } },	0.2,	2	},
+//		Quartic
+		{	[][] float64 { { 0.0, 0.0 }, { 1.0, 3.0 }, { 2.0, -1.0 }, { 3.0, 3.0 }, { 4.0, 0.0 } },	0.1,	2	},
+	}
+
+	for	_, tolerance := range	[] float64 { 0.01, 1e-4 }	{
+		for	ci, c := range	cases	{
+
+			var curves, err	= Bezier_offset ( & c.points, c.distance, tolerance )
+
+			if	err != nil	|| len ( curves ) < c.min_curves	{
+				t.Errorf ( "Case %d : expected at least %d curves, got : %v ( %v )", ci, c.min_curves, curves, err )
+				continue
+			}
+
+			for	_, curve := range	curves	{
+				if	len ( curve ) != 4	{	t.Errorf ( "Case %d : curve is not cubic %v", ci, curve )	}
+			}
+
+//			Offset points by the curve normals lie on the result
+			var derivative	= Bezier_derivative ( & c.points )
+
+			for	i := 0 ; i <= 200 ; i ++	{
+
+				var expected	= offset_point ( & c.points, & derivative, float64 ( i ) / 200.0, c.distance )
+
+				if	expected == nil	{	continue	}
+
+				var closest	= math.Inf ( 1 )
+
+				for	ii := range	curves	{
+					_, _, distance, _	:= Bezier_closest_point ( & curves [ ii ], expected )
+					closest	= math.Min ( closest, distance )
+				}
+
+				if	closest > tolerance	{
+					t.Errorf ( "Case %d, tolerance %v : offset point %v is %v away from the result", ci, tolerance, expected, closest )
+					break
+				}
+			}
+
+//			Points of the result lie on the offset curve, the distance is refined between fine samples of the offset points
+			var offset_points	[][] float64
+
+			for	i := 0 ; i <= 2000 ; i ++	{
+				offset_points	= append ( offset_points, offset_point ( & c.points, & derivative, float64 ( i ) / 2000.0, c.distance ) )
+			}
+
+			for	ii := range	curves	{
+				for	i := 0 ; i <= 50 ; i ++	{
+
+					var (
+						point		= Bezier_point ( & curves [ ii ], float64 ( i ) / 50.0 )
+						distance	= offset_curve_distance ( & c.points, & derivative, offset_points, point, c.distance )
+					)
+
+					if	distance > tolerance	{
+						t.Errorf ( "Case %d, tolerance %v : result point %v is %v away from the offset curve", ci, tolerance, point, distance )
+						break
+					}
+				}
+			}
+		}
+	}
+
+//	Offset of a line is exact
+	var curves, _	= Bezier_offset ( & [][] float64 { { 0.0, 0.0 }, { 4.0, 0.0 } }, 1.0, 0.01 )
+
+	if	fmt.Sprintf ( "%.4f", Bezier_point ( & curves [ 0 ], 0.5 ) ) != fmt.Sprintf ( "%.4f", [] float64 { 2.0, 1.0 } )	{
+		t.Errorf ( "Line offset middle expected [ 2 1 ], got : %v", curves )
+	}
+
+	if	_, err := Bezier_offset ( & [][] float64 { { 0.0, 0.0, 0.0 } }, 1.0, 0.01 ) ; err == nil	{
+		t.Error ( "Points are not 2-D but there is no error" )
+	}
+}
@@ -0,0 +1,189 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+//	Interval width when subdivision stops and the root is taken as the interval middle
+const	bernstein_root_tolerance	= 1e-12
+
+/*	Finds the closest point on a Bézier curve to the query point ( orthogonal projection )
+
+	The squared distance | B( t ) - Q |^2 is minimal at the curve end points or where its derivative is zero :
+
+		f( t ) = ( B( t ) - Q ) · B'( t )
+
+	f( t ) is a polynomial of degree 2n -1, written in Bernstein form as a product of two Bernstein polynomials :
+
+		f_k = Σ C( n, i ) * C( n -1, j ) / C( 2n -1, k ) * ( P_i - Q ) · D_j	, where i + j == k
+
+	All roots of f on [ 0, 1 ] are isolated by subdivision ( see bernstein_roots ), so every local minimum is compared, not only the one nearest to a starting guess.
+
+	Return
+
+		offset		: 0.0 <= offset <= 1.0 of the closest point
+		point		: the closest point, same as Bezier_point ( control_points, offset )
+		distance	: euclidean distance between the point and query
+		err			: control points are empty or their dimensions differ from query's
+*/
+func Bezier_closest_point ( control_points  * [][] float64, query  [] float64 )		( offset  float64, point  [] float64, distance  float64, err  error )	{
+
+	var points_len	= len ( * control_points )
+
+	if	points_len == 0	|| len ( ( * control_points ) [ 0 ] ) != len ( query )	{
+
+		return	offset, point, distance, math_tools.Arg_range_error ()
+	}
+
+	var (
+		degree		= uint ( points_len -1 )
+		candidates	= [] float64 { 0.0, 1.0 }
+	)
+
+	if	degree > 0	{
+
+		var derivative	= Bezier_derivative ( control_points )
+
+//		1-D control points of f( t ), so it can be split and evaluated as a curve
+		var distance_derivative	= bernstein_product ( * control_points, derivative, func ( point, d  [] float64 )	( dot  float64 )	{
+
+			for	di, q := range	query	{
+				dot	+= ( point [ di ] - q ) * d [ di ]
+			}
+			return
+		})
+
+		candidates	= append ( candidates, bernstein_roots ( distance_derivative, 0.0, 1.0, 0, nil )... )
+	}
+
+	distance	= math.Inf ( 1 )
+
+	for	_, t := range	candidates	{
+
+		var (
+			candidate	= Bezier_point ( control_points, t )
+			d			= points_distance ( candidate, query )
+		)
+
+		if	d < distance	{
+			offset, distance	= t, d
+			point	= append ( [] float64 ( nil ), candidate... )
+		}
+	}
+	return
+}
+
+
+/*	Product of two Bernstein polynomials of degrees n and m, combined by the function ( dot or cross product ) :
+
+		c_k = Σ w_ij * combine ( a_i, b_j )	, where i + j == k
+
+	Weights come from the product of basis polynomials, which doesn't depend on the offset t :
+
+		Bernstein_basis ( n, i, t ) * Bernstein_basis ( m, j, t ) = w_ij * Bernstein_basis ( n + m, i + j, t )
+
+	Result is a 1-D polynomial of degree n + m ( control points, see bernstein_roots )
+*/
+func bernstein_product ( a, b  [][] float64, combine  func ( a, b  [] float64 ) float64 )		( result  [][] float64 )	{
+
+	var n, m	= uint ( len ( a ) -1 ), uint ( len ( b ) -1 )
+
+	result	= make ( [][] float64, n + m +1 )
+
+	for	k := range	result	{
+		result [ k ]	= [] float64 { 0.0 }
+	}
+
+	for	i := uint ( 0 ) ; i <= n ; i ++	{
+		for	j := uint ( 0 ) ; j <= m ; j ++	{
+
+			result [ i + j ][ 0 ]	+= combine ( a [ i ], b [ j ] ) * bernstein_product_weight ( n, i, m, j )
+		}
+	}
+	return
+}
+
+/*	Weight of the basis product ( see bernstein_product ), the powers of t and 1 - t are the same on both sides :
+
+		w_ij = C( n, i ) * C( m, j ) / C( n + m, i + j )
+
+	Degrees above bernstein_log_degree are computed in log space, like Bernstein_basis, C( n + m, i + j ) overflows float64 there
+*/
+func bernstein_product_weight ( n, i, m, j  uint )		float64	{
+
+	if	n + m <= bernstein_log_degree	{
+
+		return	binomial_float ( n, i ) * binomial_float ( m, j ) / binomial_float ( n + m, i + j )
+	}
+
+	return	math.Exp (
+		math_tools.LogBinomial ( float64 ( n ), float64 ( i ) ) +
+		math_tools.LogBinomial ( float64 ( m ), float64 ( j ) ) -
+		math_tools.LogBinomial ( float64 ( n + m ), float64 ( i + j ) ),
+	)
+}
+
+
+/*	Isolates real roots of a polynomial in Bernstein form on the interval [ t_start, t_end ]
+
+	Coefficients are 1-D control points, so the polynomial is split as a curve ( Bezier_split ).
+	By the variation diminishing property a polynomial has no roots on the interval if its coefficients don't change sign,
+	such parts are dropped, others are subdivided until they are narrower than bernstein_root_tolerance.
+
+	Roots are appended to the result in ascending order, a polynomial that is identically zero has no isolated roots
+*/
+func bernstein_roots ( coefficients  [][] float64, t_start, t_end  float64, depth  int, roots  [] float64 )		[] float64	{
+
+	var (
+		negative, positive	bool
+		last	= len ( coefficients ) -1
+	)
+
+	for	_, c := range	coefficients	{
+
+		if	c [ 0 ] < 0	{	negative	= true	}
+		if	c [ 0 ] > 0	{	positive	= true	}
+	}
+
+	if	! negative && ! positive	{	return	roots	}
+
+	if	! negative || ! positive	{
+
+//		Roots can only touch the interval ends
+		if	coefficients [ 0 ][ 0 ] == 0	{	roots	= append_root ( roots, t_start )	}
+		if	coefficients [ last ][ 0 ] == 0	{	roots	= append_root ( roots, t_end )	}
+
+		return	roots
+	}
+
+	if	t_end - t_start < bernstein_root_tolerance	|| depth >= 64	{
+
+		return	append_root ( roots, ( t_start + t_end ) / 2.0 )
+	}
+
+	var (
+		t_middle	= ( t_start + t_end ) / 2.0
+		left, right	= Bezier_split ( & coefficients, 0.5 )
+	)
+	roots	= bernstein_roots ( left, t_start, t_middle, depth +1, roots )
+
+	return	bernstein_roots ( right, t_middle, t_end, depth +1, roots )
+}
+
+//	Skips the root if it is a duplicate of the last one ( found on both sides of a split )
+func append_root ( roots  [] float64, t  float64 )		[] float64	{
+
+	if	size := len ( roots ) ; size > 0	&& t - roots [ size -1 ] < 2 * bernstein_root_tolerance	{
+		return	roots
+	}
+	return	append ( roots, t )
+}
+
+func points_distance ( a, b  [] float64 )		float64	{
+
+	var sum	float64
+
+	for	di := range	a	{
+		sum	+= ( a [ di ] - b [ di ] ) * ( a [ di ] - b [ di ] )
+	}
+	return	math.Sqrt ( sum )
+}
@@ -0,0 +1,142 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"testing"
+)
+
+
+func Test_Bezier_closest_point ( t * testing.T )	{
+
+	t.Parallel ()
+
+	type Projection_test struct	{
+		points	[][] float64
+		query	[] float64
+
+		offset, distance	float64
+	}
+
+	var (
+		cases	= [...] Projection_test {
+
+//			Line
+			Projection_test {
+				points	: [][] float64 { { 0.0, 0.0 }, { 1.0, 0.0 } },
+				query	: [] float64 { 0.5, 1.0 },
+				offset	: 0.5,	distance	: 1.0,
+			},
+
+//			Line, query is beyond the end point
+			Projection_test {
+				points	: [][] float64 { { 0.0, 0.0 }, { 1.0, 0.0 } },
+				query	: [] float64 { 2.0, 0.0 },
+				offset	: 1.0,	distance	: 1.0,
+			},
+
+//			Parabola y = 4t( 1 - t ), x = 2t, query above the top
+			Projection_test {
+				points	: [][] float64 { { 0.0, 0.0 }, { 1.0, 2.0 }, { 2.0, 0.0 } },
+				query	: [] float64 { 1.0, 3.0 },
+				offset	: 0.5,	distance	: 2.0,
+			},
+
+//			Query lies on the curve
+			Projection_test {
+				points	: [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },
+				query	: [] float64 { 2.5, 4.5 },
+				offset	: 0.25,	distance	: 0.0,
+			},
+
+//			Single point
+			Projection_test {
+				points	: [][] float64 { { 1.0, 1.0 } },
+				query	: [] float64 { 4.0, 5.0 },
+				offset	: 0.0,	distance	: 5.0,
+			},
+		}
+
+		offset, distance	float64
+		point	[] float64
+		err		error
+	)
+
+	for	ci := range	cases	{
+
+		offset, point, distance, err	= Bezier_closest_point ( & cases [ ci ].points, cases [ ci ].query )
+
+		if	err != nil	||
+			fmt.Sprintf ( "%.4f %.4f", offset, distance ) !=
+			fmt.Sprintf ( "%.4f %.4f", cases [ ci ].offset, cases [ ci ].distance )	{
+
+			t.Errorf (
+				"Query %v, expected offset %.4f distance %.4f, got : %.4f %.4f %v ( %v ).\tPoints %v",
+				cases [ ci ].query, cases [ ci ].offset, cases [ ci ].distance,
+				offset, distance, point, err,
+				cases [ ci ].points,
+			)
+		}
+	}
+
+//	S-curve has several local minima of the distance, compare with a dense sampling
+	var (
+		s_curve	= [][] float64 { { 0.0, 0.0 }, { 3.0, 4.0 }, { -1.0, 4.0 }, { 2.0, 0.0 } }
+		queries	= [][] float64 { { 1.0, 2.0 }, { 1.0, 5.0 }, { -3.0, 1.0 }, { 0.9, 2.9 }, { 5.0, 5.0 } }
+		samples	= 20000
+	)
+
+	for	_, query := range	queries	{
+
+		offset, point, distance, err	= Bezier_closest_point ( & s_curve, query )
+
+		if	err != nil	{
+			t.Error ( err )
+			t.FailNow ()
+		}
+
+		if	points_distance ( point, Bezier_point ( & s_curve, offset ) ) > 1e-9	{
+			t.Errorf ( "Point %v is not on the curve at offset %v", point, offset )
+		}
+
+		for	i := 0 ; i <= samples ; i ++	{
+
+			var sampled	= points_distance ( Bezier_point ( & s_curve, float64 ( i ) / float64 ( samples ) ), query )
+
+			if	sampled < distance - 1e-9	{
+
+				t.Errorf ( "Query %v : distance %v at offset %v, but sampled %v at %v",
+					query, distance, offset, sampled, float64 ( i ) / float64 ( samples ),
+				)
+				t.FailNow ()
+			}
+		}
+	}
+
+//	High degree line x = 540t, the product polynomial is of degree 1079 ( log space weights )
+	var line	= make ( [][] float64, 541 )
+
+	for	i := range	line	{
+		line [ i ]	= [] float64 { float64 ( i ), 0.0 }
+	}
+
+	offset, point, distance, err	= Bezier_closest_point ( & line, [] float64 { 180.0, 1.0 } )
+
+	if	result, expected := fmt.Sprintf ( "%.4f %.4f", offset, distance ), fmt.Sprintf ( "%.4f %.4f", 1.0 / 3.0, 1.0 ) ; err != nil	|| result != expected	{
+		t.Errorf ( "Degree 540 line : expected offset, distance = %v, got : %v %v ( %v )", expected, result, point, err )
+	}
+
+	_, _, _, err	= Bezier_closest_point ( new ( [][] float64 ), [] float64 { 0.0 } )
+
+	if	err == nil	{
+		t.Error ( "Arguments are wrong but there is no error" )
+	}
+
+	_, _, _, err	= Bezier_closest_point ( & s_curve, [] float64 { 0.0 } )
+
+	if	err == nil	{
+		t.Error ( "Query dimensions differ but there is no error" )
+	}
+}
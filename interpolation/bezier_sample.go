@@ -0,0 +1,132 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"context"
+	"runtime"
+	"sync"
+
+	"github.com/sjbog/math_tools"
+)
+
+//	Number of offsets a worker evaluates between context checks
+const	sample_chunk_size	= 64
+
+
+/*	Evaluates a curve at every offset by a pool of goroutines
+
+	Arguments
+
+		result	: preallocated buffer, result [ i ] receives Bezier_point ( control_points, offsets [ i ] ),
+				  it must have len ( offsets ) rows of the curve dimensions
+		workers	: maximal number of goroutines, runtime.GOMAXPROCS ( 0 ) if workers <= 0
+
+	Return
+
+		err	: ctx.Err () if the context is cancelled ( some rows are not filled then ),
+			  an error if the buffer doesn't fit or the curve is empty
+
+	Results are the same as of sequential Bezier_point calls
+*/
+func Bezier_sample ( ctx  context.Context, control_points  * [][] float64, offsets  [] float64, result  [][] float64, workers  int )		error	{
+
+	if	len ( * control_points ) == 0	|| ! sample_buffer_fits ( result, len ( offsets ), len ( ( * control_points ) [ 0 ] ) )	{
+		return	math_tools.Arg_range_error ()
+	}
+
+	return	parallel_chunks ( ctx, len ( offsets ), workers, func ( start, end  int )	{
+
+		for	i := start ; i < end ; i ++	{
+			Bezier_point_to ( control_points, offsets [ i ], result [ i ] )
+		}
+	})
+}
+
+/*	Evaluates every curve at every offset by a pool of goroutines, see Bezier_sample
+
+	result [ c ][ i ] receives Bezier_point ( & curves [ c ], offsets [ i ] ), so the buffer has len ( curves ) × len ( offsets ) rows
+*/
+func Bezier_sample_curves ( ctx  context.Context, curves  [][][] float64, offsets  [] float64, result  [][][] float64, workers  int )		error	{
+
+	if	len ( result ) != len ( curves )	{	return	math_tools.Arg_range_error ()	}
+
+	for	c, curve := range	curves	{
+
+		if	len ( curve ) == 0	|| ! sample_buffer_fits ( result [ c ], len ( offsets ), len ( curve [ 0 ] ) )	{
+			return	math_tools.Arg_range_error ()
+		}
+	}
+
+	var size	= len ( offsets )
+
+	if	size == 0	{	return	ctx.Err ()	}
+
+//	Chunks are numbered through all curves : task i is the offset i % size of the curve i / size
+	return	parallel_chunks ( ctx, len ( curves ) * size, workers, func ( start, end  int )	{
+
+		for	i := start ; i < end ; i ++	{
+			Bezier_point_to ( & curves [ i / size ], offsets [ i % size ], result [ i / size ][ i % size ] )
+		}
+	})
+}
+
+
+/*	Runs the job over [ 0, total ) in chunks on a bounded pool of goroutines
+
+	Workers stop taking chunks when the context is done, its error is returned
+*/
+func parallel_chunks ( ctx  context.Context, total, workers  int, job  func ( start, end  int ) )		error	{
+
+	if	workers <= 0	{	workers	= runtime.GOMAXPROCS ( 0 )	}
+
+	var (
+		chunks	= ( total + sample_chunk_size -1 ) / sample_chunk_size
+		tasks	= make ( chan int )
+		group	sync.WaitGroup
+	)
+
+	if	workers > chunks	{	workers	= chunks	}
+
+	group.Add ( workers )
+
+	for	worker := 0 ; worker < workers ; worker ++	{
+
+		go	func ()	{
+
+			defer	group.Done ()
+
+			for	start := range	tasks	{
+
+				if	ctx.Err () != nil	{	continue	}
+
+				job ( start, min ( start + sample_chunk_size, total ) )
+			}
+		}()
+	}
+
+	for	start := 0 ; start < total ; start += sample_chunk_size	{
+
+		select	{
+			case tasks <- start :
+			case <- ctx.Done () :
+		}
+
+		if	ctx.Err () != nil	{	break	}
+	}
+	close ( tasks )
+	group.Wait ()
+
+	return	ctx.Err ()
+}
+
+func sample_buffer_fits ( buffer  [][] float64, rows, dimensions  int )		bool	{
+
+	if	len ( buffer ) != rows	{	return	false	}
+
+	for	_, row := range	buffer	{
+		if	len ( row ) != dimensions	{	return	false	}
+	}
+	return	true
+}
@@ -0,0 +1,94 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"context"
+	"fmt"
+	"testing"
+)
+
+
+func new_sample_buffer ( rows, dimensions  int )		( buffer  [][] float64 )	{
+
+	buffer	= make ( [][] float64, rows )
+
+	for	i := range	buffer	{
+		buffer [ i ]	= make ( [] float64, dimensions )
+	}
+	return
+}
+
+func Test_Bezier_sample ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		curves	= [][][] float64 {
+			{ { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },
+			{ { -2.0, 0.0 }, { -1.0, 2.0 }, { 0.0, 0.0 }, { 1.0, -2.0 }, { 2.0, 0.0 } },
+			{ { 1.0, 1.0 } },
+		}
+		offsets	= make ( [] float64, 1000 )
+	)
+
+	for	i := range	offsets	{
+		offsets [ i ]	= float64 ( i ) / float64 ( len ( offsets ) -1 )
+	}
+
+	for	_, workers := range	[] int { 0, 1, 3, 100 }	{
+
+		var all	= make ( [][][] float64, len ( curves ) )
+
+		for	c := range	curves	{
+
+			var result	= new_sample_buffer ( len ( offsets ), 2 )
+
+			if	err := Bezier_sample ( context.Background (), & curves [ c ], offsets, result, workers ) ; err != nil	{
+				t.Error ( err )
+				t.FailNow ()
+			}
+
+			all [ c ]	= new_sample_buffer ( len ( offsets ), 2 )
+
+			for	i, offset := range	offsets	{
+
+				if	fmt.Sprint ( result [ i ] ) != fmt.Sprint ( Bezier_point ( & curves [ c ], offset ) )	{
+					t.Errorf ( "Workers %d, curve %d at %v : expected %v, got : %v", workers, c, offset, Bezier_point ( & curves [ c ], offset ), result [ i ] )
+					t.FailNow ()
+				}
+			}
+		}
+
+		if	err := Bezier_sample_curves ( context.Background (), curves, offsets, all, workers ) ; err != nil	{
+			t.Error ( err )
+			t.FailNow ()
+		}
+
+		for	c := range	curves	{
+			for	i, offset := range	offsets	{
+
+				if	fmt.Sprint ( all [ c ][ i ] ) != fmt.Sprint ( Bezier_point ( & curves [ c ], offset ) )	{
+					t.Errorf ( "Workers %d, curves %d at %v : expected %v, got : %v", workers, c, offset, Bezier_point ( & curves [ c ], offset ), all [ c ][ i ] )
+					t.FailNow ()
+				}
+			}
+		}
+	}
+
+	var ctx, cancel	= context.WithCancel ( context.Background () )
+	cancel ()
+
+	if	err := Bezier_sample ( ctx, & curves [ 0 ], offsets, new_sample_buffer ( len ( offsets ), 2 ), 2 ) ; err != context.Canceled	{
+		t.Error ( "Context is cancelled, expected an error, got : ", err )
+	}
+
+	if	err := Bezier_sample ( context.Background (), & curves [ 0 ], offsets, new_sample_buffer ( 10, 2 ), 2 ) ; err == nil	{
+		t.Error ( "Buffer is too small but there is no error" )
+	}
+
+	if	err := Bezier_sample_curves ( context.Background (), curves, offsets, nil, 2 ) ; err == nil	{
+		t.Error ( "Buffer is missing but there is no error" )
+	}
+}
@@ -0,0 +1,98 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+/*	Point of a surface with its partial derivatives
+
+	Normal is the unit vector Du × Dv, it is nil if points are not 3-D or the derivatives are parallel ( degenerate patch )
+*/
+type Surface_point struct {
+
+	Point, Du, Dv, Normal	[] float64
+}
+
+/*	Evaluates a tensor-product Bézier surface at ( u, v )
+
+	Control points are an ( n +1 ) × ( m +1 ) grid of N-dimensional points, for example 4 × 4 for a bicubic patch :
+
+		S( u, v ) = Σ Σ B_i,n( u ) * B_j,m( v ) * P_i,j
+
+	Partial derivatives use differences of the neighbour points :
+
+		∂S / ∂u = n * Σ Σ B_i,n-1( u ) * B_j,m( v ) * ( P_i+1,j - P_i,j )
+		∂S / ∂v = m * Σ Σ B_i,n( u ) * B_j,m-1( v ) * ( P_i,j+1 - P_i,j )
+
+	Bernstein polynomials are computed by Bernstein_basis along each direction.
+	A derivative along a direction with a single row of points is zero.
+
+	Returns an error if the grid is empty, ragged or points dimensions differ
+*/
+func Bezier_surface_point ( control_points  * [][][] float64, u, v  float64 )		( result  Surface_point, err  error )	{
+
+	var rows	= len ( * control_points )
+
+	if	rows == 0	|| len ( ( * control_points ) [ 0 ] ) == 0	{	return	result, math_tools.Arg_range_error ()	}
+
+	var (
+		columns		= len ( ( * control_points ) [ 0 ] )
+		dimensions	= len ( ( * control_points ) [ 0 ][ 0 ] )
+		n, m		= uint ( rows -1 ), uint ( columns -1 )
+	)
+
+	for	_, row := range	* control_points	{
+
+		if	len ( row ) != columns	{	return	result, math_tools.Arg_range_error ()	}
+
+		for	_, point := range	row	{
+			if	len ( point ) != dimensions	{	return	result, math_tools.Arg_range_error ()	}
+		}
+	}
+
+	result.Point	= make ( [] float64, dimensions )
+	result.Du		= make ( [] float64, dimensions )
+	result.Dv		= make ( [] float64, dimensions )
+
+	for	i := uint ( 0 ) ; i <= n ; i ++	{
+		for	j := uint ( 0 ) ; j <= m ; j ++	{
+
+			var (
+				point	= ( * control_points ) [ i ][ j ]
+				weight	= Bernstein_basis ( n, i, u ) * Bernstein_basis ( m, j, v )
+				weight_u, weight_v	float64
+			)
+
+			if	i < n	{	weight_u	= float64 ( n ) * Bernstein_basis ( n -1, i, u ) * Bernstein_basis ( m, j, v )	}
+			if	j < m	{	weight_v	= float64 ( m ) * Bernstein_basis ( n, i, u ) * Bernstein_basis ( m -1, j, v )	}
+
+			for	di := 0 ; di < dimensions ; di ++	{
+
+				result.Point [ di ]	+= weight * point [ di ]
+
+				if	i < n	{	result.Du [ di ]	+= weight_u * ( ( * control_points ) [ i +1 ][ j ][ di ] - point [ di ] )	}
+				if	j < m	{	result.Dv [ di ]	+= weight_v * ( ( * control_points ) [ i ][ j +1 ][ di ] - point [ di ] )	}
+			}
+		}
+	}
+
+	if	dimensions == 3	{
+
+		var (
+			du, dv	= result.Du, result.Dv
+			normal	= [] float64 {
+				du [ 1 ] * dv [ 2 ] - du [ 2 ] * dv [ 1 ],
+				du [ 2 ] * dv [ 0 ] - du [ 0 ] * dv [ 2 ],
+				du [ 0 ] * dv [ 1 ] - du [ 1 ] * dv [ 0 ],
+			}
+			length	= vector_length ( normal )
+		)
+
+		if	length > 0	&& ! math.IsNaN ( length )	{
+
+			for	di := range	normal	{	normal [ di ]	/= length	}
+
+			result.Normal	= normal
+		}
+	}
+	return
+}
@@ -0,0 +1,126 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+func Test_Bezier_surface_point ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+//		Hyperbolic paraboloid z = x * y
+		saddle	= [][][] float64 {
+			{ { 0.0, 0.0, 0.0 }, { 0.0, 1.0, 0.0 } },
+			{ { 1.0, 0.0, 0.0 }, { 1.0, 1.0, 1.0 } },
+		}
+//		Flat bicubic patch
+		flat	= make ( [][][] float64, 4 )
+	)
+
+	for	i := range	flat	{
+		for	j := 0 ; j < 4 ; j ++	{
+			flat [ i ]	= append ( flat [ i ], [] float64 { float64 ( i ) / 3.0, float64 ( j ) / 3.0, 0.0 } )
+		}
+	}
+
+	for	_, uv := range	[][ 2 ] float64 { { 0.0, 0.0 }, { 0.25, 0.5 }, { 0.9, 0.3 }, { 1.0, 1.0 } }	{
+
+		var (
+			u, v	= uv [ 0 ], uv [ 1 ]
+			length	= math.Sqrt ( u * u + v * v +1 )
+
+			cases	= [] struct	{
+				grid		[][][] float64
+				expected	Surface_point
+			}	{
+				{	saddle,	Surface_point {
+						[] float64 { u, v, u * v }, [] float64 { 1.0, 0.0, v }, [] float64 { 0.0, 1.0, u },
+						[] float64 { ( 0 - v ) / length, ( 0 - u ) / length, 1.0 / length },
+					},
+				},
+				{	flat,	Surface_point {
+						[] float64 { u, v, 0.0 }, [] float64 { 1.0, 0.0, 0.0 }, [] float64 { 0.0, 1.0, 0.0 },
+						[] float64 { 0.0, 0.0, 1.0 },
+					},
+				},
+			}
+		)
+
+		for	ci, c := range	cases	{
+
+			var result, err	= Bezier_surface_point ( & c.grid, u, v )
+
+			if	err != nil	|| fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", c.expected )	{
+				t.Errorf ( "Case %d ( %v, %v ) : expected %v, got : %v ( %v )", ci, u, v, c.expected, result, err )
+			}
+		}
+	}
+
+//	Single row is a curve
+	var (
+		curve	= [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } }
+		row		= [][][] float64 { curve }
+	)
+
+	for	i := 0 ; i <= 10 ; i ++	{
+
+		var (
+			v	= float64 ( i ) / 10.0
+			result, err	= Bezier_surface_point ( & row, 0.3, v )
+			derivative	= Bezier_derivative ( & curve )
+		)
+
+		if	err != nil	|| result.Normal != nil	||
+			fmt.Sprintf ( "%.6f", result.Point ) != fmt.Sprintf ( "%.6f", Bezier_point ( & curve, v ) )	||
+			fmt.Sprintf ( "%.6f", result.Dv ) != fmt.Sprintf ( "%.6f", Bezier_point ( & derivative, v ) )	||
+			fmt.Sprintf ( "%.6f", result.Du ) != "[0.000000 0.000000]"	{
+
+			t.Errorf ( "Row at %v : expected %v, got : %v ( %v )", v, Bezier_point ( & curve, v ), result, err )
+		}
+	}
+
+//	Derivatives of a curved bicubic patch against finite differences
+	var patch	= [][][] float64 {
+		{ { 0, 0, 0 }, { 0, 1, 1 }, { 0, 2, -1 }, { 0, 3, 0 } },
+		{ { 1, 0, 2 }, { 1, 1, 3 }, { 1, 2, 0 }, { 1, 3, 1 } },
+		{ { 2, 0, -1 }, { 2, 1, 0 }, { 2, 2, 4 }, { 2, 3, 2 } },
+		{ { 3, 0, 0 }, { 3, 1, 1 }, { 3, 2, 1 }, { 3, 3, 0 } },
+	}
+
+	for	_, uv := range	[][ 2 ] float64 { { 0.2, 0.7 }, { 0.5, 0.5 }, { 0.8, 0.1 } }	{
+
+		var (
+			h	= 1e-6
+			result, _	= Bezier_surface_point ( & patch, uv [ 0 ], uv [ 1 ] )
+			u_next, _	= Bezier_surface_point ( & patch, uv [ 0 ] + h, uv [ 1 ] )
+			v_next, _	= Bezier_surface_point ( & patch, uv [ 0 ], uv [ 1 ] + h )
+		)
+
+		for	di := range	result.Point	{
+
+			if	math.Abs ( ( u_next.Point [ di ] - result.Point [ di ] ) / h - result.Du [ di ] ) > 1e-4	||
+				math.Abs ( ( v_next.Point [ di ] - result.Point [ di ] ) / h - result.Dv [ di ] ) > 1e-4	{
+
+				t.Errorf ( "( %v ) : derivatives %v %v differ from finite differences", uv, result.Du, result.Dv )
+				break
+			}
+		}
+	}
+
+	for	_, grid := range	[][][][] float64 {
+		{},
+		{ { { 0, 0 }, { 1, 1 } }, { { 0, 0 } } },
+		{ { { 0, 0 }, { 1, 1, 1 } } },
+	}	{
+		if	_, err := Bezier_surface_point ( & grid, 0.5, 0.5 ) ; err == nil	{
+			t.Error ( "Grid is wrong but there is no error : ", grid )
+		}
+	}
+}
@@ -6,6 +6,8 @@ package	interpolation
 import	(
 	"fmt"
 	"testing"
+
+	"github.com/sjbog/math_tools"
 )
 
 
@@ -201,4 +203,338 @@ func Test_Bezier_point ( t * testing.T )	{
 	if	result != nil	{
 		t.Error ( "Arguments are wrong but there is no error, result : ", result )
 	}
-}
\ No newline at end of file
+}
+
+func Test_Bezier_derivative ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		points	= [][] float64 {
+			[] float64 { 0.0, 0.0 },
+			[] float64 { 0.0, 8.0 },
+			[] float64 { 16.0, 8.0 },
+			[] float64 { 16.0, 0.0 },
+		}
+		expected	= [][] float64 {
+			[] float64 { 0.0, 24.0 },
+			[] float64 { 48.0, 0.0 },
+			[] float64 { 0.0, -24.0 },
+		}
+
+		result	= Bezier_derivative ( & points )
+	)
+
+	if	fmt.Sprint ( result ) != fmt.Sprint ( expected )	{
+		t.Errorf ( "Expected = %v, got : %v", expected, result )
+	}
+
+//	Derivative by the basis of degree n -1 equals the central difference of the curve
+	for	i := 1 ; i < 10 ; i ++	{
+
+		var (
+			offset	= float64 ( i ) / 10.0
+			h		= 1e-6
+
+			before, after	= Bezier_point ( & points, offset - h ), Bezier_point ( & points, offset + h )
+			difference		= [] float64 { ( after [ 0 ] - before [ 0 ] ) / ( 2 * h ), ( after [ 1 ] - before [ 1 ] ) / ( 2 * h ) }
+			derivative		= make ( [] float64, 2 )
+		)
+
+		for	pi, point := range	result	{
+			for	di := range	derivative	{
+				derivative [ di ]	+= point [ di ] * Bernstein_basis ( uint ( len ( result ) -1 ), uint ( pi ), offset )
+			}
+		}
+
+		if	fmt.Sprintf ( "%.4f", derivative ) != fmt.Sprintf ( "%.4f", difference )	{
+			t.Errorf ( "Offset %.1f : expected = %.4f, got : %.4f", offset, difference, derivative )
+		}
+	}
+
+	if	result = Bezier_derivative ( & [][] float64 { { 1.0, 1.0 } } ) ; result != nil	{
+		t.Error ( "Derivative of a single point should be nil, got : ", result )
+	}
+}
+
+func Test_Bezier_split ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		points	= [][] float64 {
+			[] float64 { -2.0,  0.0 },
+			[] float64 { -1.0,  2.0 },
+			[] float64 {  0.0,  0.0 },
+			[] float64 {  1.0, -2.0 },
+			[] float64 {  2.0,  0.0 },
+		}
+		split_offset	= 0.3
+	)
+
+	var left, right	= Bezier_split ( & points, split_offset )
+
+	if	len ( left ) != len ( points )	|| len ( right ) != len ( points )	{
+		t.Errorf ( "Expected %d points, got : %v %v", len ( points ), left, right )
+		t.FailNow ()
+	}
+
+	for	i := 0 ; i <= 10 ; i ++	{
+
+		var (
+			offset	= float64 ( i ) / 10.0
+
+			expected_left	= Bezier_point ( & points, offset * split_offset )
+			expected_right	= Bezier_point ( & points, split_offset + offset * ( 1.0 - split_offset ) )
+		)
+
+		if	fmt.Sprintf ( "%.6f", Bezier_point ( & left, offset ) ) != fmt.Sprintf ( "%.6f", expected_left )	||
+			fmt.Sprintf ( "%.6f", Bezier_point ( & right, offset ) ) != fmt.Sprintf ( "%.6f", expected_right )	{
+
+			t.Errorf ( "Offset %.2f : expected %v %v, got : %v %v",
+				offset, expected_left, expected_right,
+				Bezier_point ( & left, offset ), Bezier_point ( & right, offset ),
+			)
+		}
+	}
+
+	if	points [ 1 ][ 1 ] != 2.0	{
+		t.Error ( "Input points are modified : ", points )
+	}
+}
+
+//	Not parallel : testing.AllocsPerRun counts allocations of the whole program
+func Test_Bezier_point_to ( t * testing.T )	{
+
+	var (
+		curves	= [][][] float64 {
+			{ { 1.0, 2.0, 3.0 } },
+			{ { 0.0, 0.0 }, { 4.0, 2.0 } },
+			{ { 0.0, 0.0 }, { 2.0, 4.0 }, { 4.0, 0.0 } },
+			{ { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },
+			{ { -2.0, 0.0 }, { -1.0, 2.0 }, { 0.0, 0.0 }, { 1.0, -2.0 }, { 2.0, 0.0 }, { 3.0, 1.0 } },
+		}
+		destination	= make ( [] float64, 3 )
+	)
+
+	for	c := range	curves	{
+		for	_, offset := range	[] float64 { 0.0, 0.25, 0.5, 0.9, 1.0 }	{
+
+			var (
+				expected	= fmt.Sprint ( Bezier_point ( & curves [ c ], offset ) )
+				result		= Bezier_point_to ( & curves [ c ], offset, destination )
+			)
+
+			if	fmt.Sprint ( result ) != expected	|| & result [ 0 ] != & destination [ 0 ]	{
+				t.Errorf ( "Bezier_point_to ( %v, %v ) expected = %v in the destination, got : %v", curves [ c ], offset, expected, result )
+			}
+
+			if	allocs := testing.AllocsPerRun ( 10, func ()	{ Bezier_point_to ( & curves [ c ], offset, destination ) } ) ; allocs != 0	{
+				t.Errorf ( "Bezier_point_to ( %v, %v ) expected = 0 allocations, got : %v", curves [ c ], offset, allocs )
+			}
+		}
+	}
+
+//	Single point result must not alias the control point
+	for	_, result := range	[][] float64 { Bezier_point ( & curves [ 0 ], 0.5 ), Bezier_point_to ( & curves [ 0 ], 0.5, destination ) }	{
+
+		result [ 0 ]	= 100.0
+
+		if	curves [ 0 ][ 0 ][ 0 ] != 1.0	{
+			t.Errorf ( "Changing the result modified the control point : %v", curves [ 0 ][ 0 ] )
+			curves [ 0 ][ 0 ][ 0 ]	= 1.0
+		}
+	}
+
+	if	result := Bezier_point_to ( & curves [ 3 ], 0.5, destination [ : 0 ] ) ; len ( result ) != 2	|| & result [ 0 ] != & destination [ 0 ]	{
+		t.Errorf ( "Bezier_point_to expected to reuse the capacity of the destination, got : %v", result )
+	}
+
+	if	result := Bezier_point_to ( & curves [ 0 ], 0.5, destination [ : 0 : 1 ] ) ; fmt.Sprint ( result ) != "[1 2 3]"	{
+		t.Errorf ( "Bezier_point_to with a small destination expected = [1 2 3], got : %v", result )
+	}
+
+//	Control point or a part of the points array as the destination : a new slice, the control points are not modified
+	var (
+		values	= [] float64 { 0.0, 0.0, 1.0, 2.0, 2.0, 0.0, 3.0, 2.0, 4.0, 0.0 }
+		points	= [][] float64 { values [ 0 : 2 ], values [ 2 : 4 ], values [ 4 : 6 ], values [ 6 : 8 ], values [ 8 : 10 ] }
+	)
+
+	for	_, destination := range	[][] float64 { points [ 2 ], values [ 3 : 5 ], values [ 1 : 1 ] }	{
+
+		var expected	= fmt.Sprint ( Bezier_point ( & points, 0.5 ) )
+
+		if	result := Bezier_point_to ( & points, 0.5, destination ) ; fmt.Sprint ( result ) != expected	|| fmt.Sprint ( points ) != "[[0 0] [1 2] [2 0] [3 2] [4 0]]"	{
+			t.Errorf ( "Bezier_point_to into a control point expected = %v, got : %v, points %v", expected, result, points )
+		}
+	}
+}
+
+func benchmark_bezier_point_to ( b * testing.B, control_points  [][] float64 )	{
+
+	var destination	= make ( [] float64, len ( control_points [ 0 ] ) )
+
+	b.ReportAllocs ()
+
+	for	i := 0 ; i < b.N ; i ++	{
+		Bezier_point_to ( & control_points, float64 ( i % 101 ) / 100.0, destination )
+	}
+}
+
+func Benchmark_Bezier_point_to_cubic ( b * testing.B )	{
+	benchmark_bezier_point_to ( b, [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } } )
+}
+
+func Benchmark_Bezier_point_to_degree_6 ( b * testing.B )	{
+	benchmark_bezier_point_to ( b, [][] float64 { { -2.0, 0.0 }, { -1.0, 2.0 }, { 0.0, 0.0 }, { 1.0, -2.0 }, { 2.0, 0.0 }, { 3.0, 1.0 }, { 4.0, 4.0 } } )
+}
+
+func Benchmark_Bezier_point_cubic ( b * testing.B )	{
+
+	var control_points	= [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } }
+
+	b.ReportAllocs ()
+
+	for	i := 0 ; i < b.N ; i ++	{
+		Bezier_point ( & control_points, float64 ( i % 101 ) / 100.0 )
+	}
+}
+
+func Test_Bezier_point_generic ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var curves	= [][][] float64 {
+		{ { 1.0, 2.0, 3.0 } },
+		{ { 0.0, 0.0 }, { 4.0, 2.0 } },
+		{ { 0.0, 0.0 }, { 2.0, 4.0 }, { 4.0, 0.0 } },
+		{ { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } },
+		{ { -2.0, 0.0 }, { -1.0, 2.0 }, { 0.0, 0.0 }, { 1.0, -2.0 }, { 2.0, 0.0 }, { 3.0, 1.0 } },
+	}
+
+	for	_, curve := range	curves	{
+
+		var curve32	= make ( [][] float32, len ( curve ) )
+
+		for	i, point := range	curve	{
+			for	_, value := range	point	{
+				curve32 [ i ]	= append ( curve32 [ i ], float32 ( value ) )
+			}
+		}
+
+		for	_, offset := range	[] float64 { 0.0, 0.25, 0.5, 0.9, 1.0 }	{
+
+			var (
+				expected	= Bezier_point ( & curve, offset )
+				result		= Bezier_point_generic ( & curve32, float32 ( offset ) )
+			)
+
+			if	fmt.Sprint ( Bezier_point_generic ( & curve, offset ) ) != fmt.Sprint ( expected )	{
+				t.Errorf ( "Bezier_point_generic [ float64 ] ( %v, %v ) expected = %v, got : %v", curve, offset, expected, Bezier_point_generic ( & curve, offset ) )
+			}
+
+			for	di := range	expected	{
+
+				if	fmt.Sprintf ( "%.4f", result [ di ] ) != fmt.Sprintf ( "%.4f", expected [ di ] )	{
+					t.Errorf ( "Bezier_point_generic [ float32 ] ( %v, %v ) expected = %v, got : %v", curve, offset, expected, result )
+					break
+				}
+			}
+		}
+	}
+
+	for	n := uint ( 0 ) ; n <= 7 ; n ++	{
+		for	i := uint ( 0 ) ; i <= n ; i ++	{
+
+			var (
+				expected	= Bernstein_basis ( n, i, 0.3 )
+				result		= Bernstein_basis_generic [ float32 ] ( n, i, 0.3 )
+			)
+
+			if	fmt.Sprintf ( "%.4f", result ) != fmt.Sprintf ( "%.4f", expected )	{
+				t.Errorf ( "Bernstein_basis_generic [ float32 ] ( %d, %d, 0.3 ) expected = %.4f, got : %.4f", n, i, expected, result )
+			}
+		}
+	}
+}
+
+//	Binomial coefficients of degree 80 overflow uint64, the basis still sums up to 1
+func Test_Bezier_point_large_degree ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var control_points	= make ( [][] float64, 81 )
+
+	for	i := range	control_points	{
+		control_points [ i ]	= [] float64 { 2.0, float64 ( i ) }
+	}
+
+	for	_, offset := range	[] float64 { 0.1, 0.5, 0.75 }	{
+
+		var (
+			result	= Bezier_point ( & control_points, offset )
+			sum		float64
+		)
+
+		for	i := range	control_points	{
+			sum	+= Bernstein_basis ( 80, uint ( i ), offset )
+		}
+
+//		Points lie on a line, x is constant and y is linear in the offset
+		if	fmt.Sprintf ( "%.4f %.4f %.4f", result [ 0 ], result [ 1 ], sum ) != fmt.Sprintf ( "2.0000 %.4f 1.0000", 80 * offset )	{
+			t.Errorf ( "Degree 80 curve at %.4f expected = [2.0000 %.4f] and basis sum 1.0000, got : %v, %.4f", offset, 80 * offset, result, sum )
+		}
+	}
+}
+
+//	Pascal's triangle returned by math_tools is a copy of the rows, which Bernstein basis reads
+func Test_Bezier_point_pascal_copy ( t * testing.T )	{
+
+	t.Parallel ()
+
+	math_tools.Pascal_table_float ( 7 ) [ 7 ][ 3 ]	= 0
+
+	var control_points	= make ( [][] float64, 8 )
+
+	for	i := range	control_points	{
+		control_points [ i ]	= [] float64 { 1.0 }
+	}
+
+	if	result := Bezier_point ( & control_points, 0.5 ) ; fmt.Sprintf ( "%.4f %.4f", result [ 0 ], Bernstein_basis ( 7, 3, 0.5 ) ) != "1.0000 0.2734"	{
+		t.Errorf ( "Degree 7 curve of ones expected = [1.0000] and basis 0.2734, got : %v, %.4f", result, Bernstein_basis ( 7, 3, 0.5 ) )
+	}
+}
+
+func Test_Bernstein_basis_log_space ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [] struct {
+		n, i	uint
+		offset, expected	float64
+	}{
+		{ 5000, 2500, 0.5, 0.011283227495495618 },
+		{ 3000, 900, 0.3, 0.015892580081007565 },
+		{ 3000, 0, 0.0, 1.0 },
+		{ 3000, 3000, 1.0, 1.0 },
+		{ 3000, 10, 0.0, 0.0 },
+		{ 3000, 10, 1.0, 0.0 },
+	}
+
+	for	_, c := range	cases	{
+
+		if	result := Bernstein_basis ( c.n, c.i, c.offset ) ; fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", c.expected )	{
+			t.Errorf ( "Bernstein_basis ( %d, %d, %.4f ) expected = %.6f, got : %.6f", c.n, c.i, c.offset, c.expected, result )
+		}
+	}
+
+	var control_points	= make ( [][] float64, 2001 )
+
+	for	i := range	control_points	{
+		control_points [ i ]	= [] float64 { float64 ( i ) }
+	}
+
+	if	result := Bezier_point ( & control_points, 0.25 ) ; fmt.Sprintf ( "%.4f", result [ 0 ] ) != "500.0000"	{
+		t.Errorf ( "Degree 2000 curve at 0.25 expected = [500.0000], got : %v", result )
+	}
+}
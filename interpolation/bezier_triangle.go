@@ -0,0 +1,186 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+/*	Triangular Bézier patches of degree n over barycentric coordinates ( u, v, w ), u + v + w == 1
+
+		T( u, v, w ) = Σ n ! / ( i ! j ! k ! ) * u^i v^j w^k * P_ijk	, where i + j + k == n
+
+	Control points are listed row by row, from the corner P_n00 to the edge i == 0, within a row k grows :
+
+		P_n00,  P_n-1,1,0  P_n-1,0,1,  P_n-2,2,0  P_n-2,1,1  P_n-2,0,2,  ...  P_0,0,n
+
+	so the index of P_ijk is r ( r +1 ) / 2 + k, where r = n - i, and there are ( n +1 )( n +2 ) / 2 points.
+	Corners P_n00, P_0n0 and P_00n are at ( 1, 0, 0 ), ( 0, 1, 0 ) and ( 0, 0, 1 ).
+*/
+
+//	Index of the control point P_ijk ( see above ), n = i + j + k
+func triangle_index ( n, i, k  uint )		int	{
+
+	var r	= n - i
+
+	return	int ( r * ( r +1 ) / 2 + k )
+}
+
+//	Degree of a triangular patch by the number of its control points, false if the number is not triangular
+func triangle_degree ( points_len  int )		( degree  uint, ok  bool )	{
+
+	for	degree = 0 ; int ( ( degree +1 ) * ( degree +2 ) / 2 ) < points_len ; degree ++	{}
+
+	return	degree, points_len > 0	&& int ( ( degree +1 ) * ( degree +2 ) / 2 ) == points_len
+}
+
+
+/*	Evaluates a triangular patch at barycentric coordinates ( u, v, w )
+
+	Weights are trinomial coefficients ( math_tools.Trinomial_coefficient ).
+	Returns an error if the number of control points is not triangular or the weights overflow uint64
+*/
+func Bezier_triangle_point ( control_points  * [][] float64, u, v, w  float64 )		( result  [] float64, err  error )	{
+
+	var degree, ok	= triangle_degree ( len ( * control_points ) )
+
+	if	! ok	{	return	result, math_tools.Arg_range_error ()	}
+
+	result	= make ( [] float64, len ( ( * control_points ) [ 0 ] ) )
+
+	for	i := uint ( 0 ) ; i <= degree ; i ++	{
+		for	j := uint ( 0 ) ; i + j <= degree ; j ++	{
+
+			var trinomial	uint64
+
+			if	trinomial, err	= math_tools.Trinomial_coefficient ( uint64 ( degree ), uint64 ( i ), uint64 ( j ) ) ; err != nil	{	return	nil, err	}
+
+			var (
+				k		= degree - i - j
+				weight	= float64 ( trinomial ) *
+					math.Pow ( u, float64 ( i ) ) * math.Pow ( v, float64 ( j ) ) * math.Pow ( w, float64 ( k ) )
+				point	= ( * control_points ) [ triangle_index ( degree, i, k ) ]
+			)
+
+			for	di := range	result	{
+				result [ di ]	+= weight * point [ di ]
+			}
+		}
+	}
+	return
+}
+
+
+/*	Directional derivative of a triangular patch at ( u, v, w )
+
+	Direction ( du, dv, dw ) is a difference of barycentric coordinates, du + dv + dw == 0.
+	For example ( -1, 1, 0 ) is the derivative along the edge from P_n00 to P_0n0 :
+
+		D T = n * Σ n -1 ! / ( i ! j ! k ! ) * u^i v^j w^k * ( du P_i+1,j,k + dv P_i,j+1,k + dw P_i,j,k+1 )	, where i + j + k == n -1
+*/
+func Bezier_triangle_derivative ( control_points  * [][] float64, u, v, w, du, dv, dw  float64 )		( result  [] float64, err  error )	{
+
+	var degree, ok	= triangle_degree ( len ( * control_points ) )
+
+	if	! ok	{	return	result, math_tools.Arg_range_error ()	}
+
+	result	= make ( [] float64, len ( ( * control_points ) [ 0 ] ) )
+
+	if	degree == 0	{	return	}
+
+	var n	= degree -1
+
+	for	i := uint ( 0 ) ; i <= n ; i ++	{
+		for	j := uint ( 0 ) ; i + j <= n ; j ++	{
+
+			var trinomial	uint64
+
+			if	trinomial, err	= math_tools.Trinomial_coefficient ( uint64 ( n ), uint64 ( i ), uint64 ( j ) ) ; err != nil	{	return	nil, err	}
+
+			var (
+				k		= n - i - j
+				weight	= float64 ( degree ) * float64 ( trinomial ) *
+					math.Pow ( u, float64 ( i ) ) * math.Pow ( v, float64 ( j ) ) * math.Pow ( w, float64 ( k ) )
+
+				p_u	= ( * control_points ) [ triangle_index ( degree, i +1, k ) ]
+				p_v	= ( * control_points ) [ triangle_index ( degree, i, k ) ]
+				p_w	= ( * control_points ) [ triangle_index ( degree, i, k +1 ) ]
+			)
+
+			for	di := range	result	{
+				result [ di ]	+= weight * ( du * p_u [ di ] + dv * p_v [ di ] + dw * p_w [ di ] )
+			}
+		}
+	}
+	return
+}
+
+
+/*	Splits a triangular patch at the point ( u, v, w ) into three patches of the same degree ( de Casteljau algorithm )
+
+	Every level r of de Casteljau pyramid has points for i + j + k == n - r :
+
+		b^r_ijk = u * b^r-1_i+1,j,k  +  v * b^r-1_i,j+1,k  +  w * b^r-1_i,j,k+1
+
+	Each sub-patch replaces one corner by the point T( u, v, w ) :
+
+		first	: ( T, P_0n0, P_00n ), its points are b^i_0jk
+		second	: ( P_n00, T, P_00n ), its points are b^j_i0k
+		third	: ( P_n00, P_0n0, T ), its points are b^k_ij0
+*/
+func Bezier_triangle_split ( control_points  * [][] float64, u, v, w  float64 )		( first, second, third  [][] float64, err  error )	{
+
+	var degree, ok	= triangle_degree ( len ( * control_points ) )
+
+	if	! ok	{	return	first, second, third, math_tools.Arg_range_error ()	}
+
+	var (
+		dimensions	= len ( ( * control_points ) [ 0 ] )
+//		Pyramid levels, level r has degree n - r
+		levels	= make ( [][][] float64, degree +1 )
+	)
+	levels [ 0 ]	= * control_points
+
+	for	r := uint ( 1 ) ; r <= degree ; r ++	{
+
+		var (
+			n		= degree - r
+			level	= make ( [][] float64, ( n +1 ) * ( n +2 ) / 2 )
+			previous	= levels [ r -1 ]
+		)
+
+		for	i := uint ( 0 ) ; i <= n ; i ++	{
+			for	k := uint ( 0 ) ; i + k <= n ; k ++	{
+
+				var (
+					point	= make ( [] float64, dimensions )
+					p_u	= previous [ triangle_index ( n +1, i +1, k ) ]
+					p_v	= previous [ triangle_index ( n +1, i, k ) ]
+					p_w	= previous [ triangle_index ( n +1, i, k +1 ) ]
+				)
+
+				for	di := range	point	{
+					point [ di ]	= u * p_u [ di ] + v * p_v [ di ] + w * p_w [ di ]
+				}
+				level [ triangle_index ( n, i, k ) ]	= point
+			}
+		}
+		levels [ r ]	= level
+	}
+
+	first	= make ( [][] float64, len ( * control_points ) )
+	second	= make ( [][] float64, len ( * control_points ) )
+	third	= make ( [][] float64, len ( * control_points ) )
+
+	for	i := uint ( 0 ) ; i <= degree ; i ++	{
+		for	k := uint ( 0 ) ; i + k <= degree ; k ++	{
+
+			var (
+				j		= degree - i - k
+				index	= triangle_index ( degree, i, k )
+			)
+
+			first [ index ]		= append ( [] float64 ( nil ), levels [ i ][ triangle_index ( degree - i, 0, k ) ]... )
+			second [ index ]	= append ( [] float64 ( nil ), levels [ j ][ triangle_index ( degree - j, i, k ) ]... )
+			third [ index ]		= append ( [] float64 ( nil ), levels [ k ][ triangle_index ( degree - k, i, 0 ) ]... )
+		}
+	}
+	return
+}
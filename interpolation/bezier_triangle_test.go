@@ -0,0 +1,151 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+var	triangle_quadratic	= [][] float64 {
+	{ 0.0, 0.0, 0.0 },
+	{ 1.0, 0.0, 1.0 },	{ 0.0, 1.0, 1.0 },
+	{ 2.0, 0.0, 0.0 },	{ 1.0, 1.0, 2.0 },	{ 0.0, 2.0, 0.0 },
+}
+
+func Test_Bezier_triangle_point ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var linear	= [][] float64 { { 0.0, 0.0 }, { 4.0, 0.0 }, { 0.0, 2.0 } }
+
+	for	_, uvw := range	[][ 3 ] float64 { { 1, 0, 0 }, { 0, 1, 0 }, { 0, 0, 1 }, { 0.2, 0.3, 0.5 } }	{
+
+		var (
+			u, v, w		= uvw [ 0 ], uvw [ 1 ], uvw [ 2 ]
+			result, err	= Bezier_triangle_point ( & linear, u, v, w )
+			expected	= [] float64 { 4.0 * v, 2.0 * w }
+		)
+
+		if	err != nil	|| fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", expected )	{
+			t.Errorf ( "( %v ) : expected %v, got : %v ( %v )", uvw, expected, result, err )
+		}
+	}
+
+//	Corners and the middle of the quadratic patch
+	for	_, c := range	[] struct	{
+		uvw			[ 3 ] float64
+		expected	[] float64
+	}	{
+		{	[ 3 ] float64 { 1, 0, 0 },	[] float64 { 0, 0, 0 }	},
+		{	[ 3 ] float64 { 0, 1, 0 },	[] float64 { 2, 0, 0 }	},
+		{	[ 3 ] float64 { 0, 0, 1 },	[] float64 { 0, 2, 0 }	},
+		{	[ 3 ] float64 { 0, 0.5, 0.5 },	[] float64 { 1, 1, 1 }	},
+		{	[ 3 ] float64 { 0.5, 0.5, 0 },	[] float64 { 1, 0, 0.5 }	},
+	}	{
+		var result, err	= Bezier_triangle_point ( & triangle_quadratic, c.uvw [ 0 ], c.uvw [ 1 ], c.uvw [ 2 ] )
+
+		if	err != nil	|| fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", c.expected )	{
+			t.Errorf ( "( %v ) : expected %v, got : %v ( %v )", c.uvw, c.expected, result, err )
+		}
+	}
+
+	if	_, err := Bezier_triangle_point ( & [][] float64 { { 0 }, { 1 }, { 2 }, { 3 } }, 0.3, 0.3, 0.4 ) ; err == nil	{
+		t.Error ( "Number of points is not triangular but there is no error" )
+	}
+}
+
+func Test_Bezier_triangle_derivative ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var h	= 1e-6
+
+	for	_, direction := range	[][ 3 ] float64 { { -1, 1, 0 }, { -1, 0, 1 }, { 0, -1, 1 } }	{
+		for	_, uvw := range	[][ 3 ] float64 { { 0.2, 0.3, 0.5 }, { 0.6, 0.1, 0.3 } }	{
+
+			var (
+				u, v, w		= uvw [ 0 ], uvw [ 1 ], uvw [ 2 ]
+				result, err	= Bezier_triangle_derivative ( & triangle_quadratic, u, v, w, direction [ 0 ], direction [ 1 ], direction [ 2 ] )
+
+				point, _	= Bezier_triangle_point ( & triangle_quadratic, u, v, w )
+				next, _		= Bezier_triangle_point ( & triangle_quadratic, u + h * direction [ 0 ], v + h * direction [ 1 ], w + h * direction [ 2 ] )
+			)
+
+			if	err != nil	{
+				t.Error ( err )
+				t.FailNow ()
+			}
+
+			for	di := range	result	{
+
+				if	math.Abs ( ( next [ di ] - point [ di ] ) / h - result [ di ] ) > 1e-4	{
+					t.Errorf ( "( %v ) along %v : derivative %v differs from finite differences", uvw, direction, result )
+					break
+				}
+			}
+		}
+	}
+}
+
+func Test_Bezier_triangle_split ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		u, v, w	= 0.2, 0.3, 0.5
+
+		first, second, third, err	= Bezier_triangle_split ( & triangle_quadratic, u, v, w )
+		middle, _	= Bezier_triangle_point ( & triangle_quadratic, u, v, w )
+	)
+
+	if	err != nil	{
+		t.Error ( err )
+		t.FailNow ()
+	}
+
+//	Sub-patch point ( s1, s2, s3 ) is the original point at s1 * corner1 + s2 * corner2 + s3 * corner3
+	var cases	= [...] struct	{
+		patch	[][] float64
+		corners	[ 3 ][ 3 ] float64
+	}	{
+		{	first,	[ 3 ][ 3 ] float64 { { u, v, w }, { 0, 1, 0 }, { 0, 0, 1 } }	},
+		{	second,	[ 3 ][ 3 ] float64 { { 1, 0, 0 }, { u, v, w }, { 0, 0, 1 } }	},
+		{	third,	[ 3 ][ 3 ] float64 { { 1, 0, 0 }, { 0, 1, 0 }, { u, v, w } }	},
+	}
+
+	for	ci, c := range	cases	{
+
+		if	corner, _ := Bezier_triangle_point ( & c.patch, 0, 0, 0 ) ; len ( corner ) != len ( middle )	{
+			t.Errorf ( "Patch %d has %d points", ci, len ( c.patch ) )
+			continue
+		}
+
+		for	_, s := range	[][ 3 ] float64 { { 1, 0, 0 }, { 0.1, 0.6, 0.3 }, { 0.25, 0.25, 0.5 } }	{
+
+			var original	[ 3 ] float64
+
+			for	corner := range	c.corners	{
+				for	di := range	original	{
+					original [ di ]	+= s [ corner ] * c.corners [ corner ][ di ]
+				}
+			}
+
+			var (
+				result, _	= Bezier_triangle_point ( & c.patch, s [ 0 ], s [ 1 ], s [ 2 ] )
+				expected, _	= Bezier_triangle_point ( & triangle_quadratic, original [ 0 ], original [ 1 ], original [ 2 ] )
+			)
+
+			if	fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", expected )	{
+				t.Errorf ( "Patch %d at %v : expected %v, got : %v", ci, s, expected, result )
+			}
+		}
+	}
+
+	if	fmt.Sprintf ( "%.6f", first [ 0 ] ) != fmt.Sprintf ( "%.6f", middle )	{
+		t.Errorf ( "Split point expected %v, got : %v", middle, first [ 0 ] )
+	}
+}
@@ -0,0 +1,311 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "math" ; "github.com/sjbog/math_tools" )
+
+//	Continuity at the joint of two path segments, each level includes the previous ones
+type Continuity	int
+
+const	(
+	Continuity_none	Continuity	= iota
+//	Segments are connected : end point of a segment is the start point of the next one
+	Continuity_C0
+//	Tangents at the joint have the same direction ( derivatives may differ in length )
+	Continuity_G1
+//	Derivatives at the joint are equal
+	Continuity_C1
+)
+
+/*	Composite Bézier path : a chain of curves ( segments ), which are evaluated by Bezier_point
+
+	A global offset 0.0 <= offset <= 1.0 is shared equally by the segments,
+	segment i covers [ i / n, ( i +1 ) / n ] for n segments.
+*/
+type Path struct {
+
+	Segments	[][][] float64
+//	Path ends with a line back to its start ( SVG "Z" command ), the line is one of the segments
+	Closed		bool
+}
+
+//	Gauss-Legendre 5 point quadrature on [ -1, 1 ]
+var (
+	gauss_nodes		= [ 5 ] float64 { 0.0, -0.5384693101056831, 0.5384693101056831, -0.9061798459386640, 0.9061798459386640 }
+	gauss_weights	= [ 5 ] float64 { 0.5688888888888889, 0.4786286704993665, 0.4786286704993665, 0.2369268850561891, 0.2369268850561891 }
+)
+
+
+/*	Appends a segment ( control points are not copied )
+
+	Returns an error if the segment is empty or its dimensions differ from the path's
+*/
+func ( self  * Path )	Append ( segment  [][] float64 )		error	{
+
+	if	len ( segment ) == 0	||
+		len ( self.Segments ) > 0	&& len ( segment [ 0 ] ) != len ( self.Segments [ 0 ][ 0 ] )	{
+
+		return	math_tools.Arg_range_error ()
+	}
+
+	self.Segments	= append ( self.Segments, segment )
+	return	nil
+}
+
+/*	Finds the segment and its own offset for a global offset ( out of range values are clamped )
+
+	Returns an error if the path is empty
+*/
+func ( self  * Path )	Segment_offset ( offset  float64 )		( index  int, segment_offset  float64, err  error )	{
+
+	if	len ( self.Segments ) == 0	{	return	0, 0, math_tools.Arg_range_error ()	}
+
+	var (
+		segments_num	= len ( self.Segments )
+		scaled			= math.Max ( 0, math.Min ( 1, offset ) ) * float64 ( segments_num )
+	)
+
+	index	= int ( scaled )
+
+	if	index >= segments_num	{	index	= segments_num -1	}
+
+	return	index, scaled - float64 ( index ), nil
+}
+
+//	Point at the global offset, nil for an empty path
+func ( self  * Path )	Point ( offset  float64 )		[] float64	{
+
+	var index, segment_offset, err	= self.Segment_offset ( offset )
+
+	if	err != nil	{	return	nil	}
+
+	return	Bezier_point ( & self.Segments [ index ], segment_offset )
+}
+
+
+//	Total arc length of the path
+func ( self  * Path )	Length ()		( length  float64 )	{
+
+	for	i := range	self.Segments	{
+		length	+= segment_length ( & self.Segments [ i ], 1.0 )
+	}
+	return
+}
+
+/*	Point at the arc length from the path start ( out of range values are clamped ), nil for an empty path
+
+	Segment offset is found by Newton iterations on the length integral ( with bisection fallback )
+*/
+func ( self  * Path )	Point_at_length ( length  float64 )		[] float64	{
+
+	if	len ( self.Segments ) == 0	{	return	nil	}
+
+	var last	= len ( self.Segments ) -1
+
+	for	i := range	self.Segments	{
+
+		var current	= segment_length ( & self.Segments [ i ], 1.0 )
+
+		if	length > current	&& i < last	{
+			length	-= current
+			continue
+		}
+
+		var (
+			derivative	= Bezier_derivative ( & self.Segments [ i ] )
+			low, high	= 0.0, 1.0
+			offset		= math.Max ( 0, math.Min ( 1, length / current ) )
+		)
+
+		if	current == 0	|| length <= 0	{	return	Bezier_point ( & self.Segments [ i ], 0.0 )	}
+		if	length >= current	{	return	Bezier_point ( & self.Segments [ i ], 1.0 )	}
+
+		for	iteration := 0 ; iteration < 32 ; iteration ++	{
+
+			var difference	= segment_length ( & self.Segments [ i ], offset ) - length
+
+			if	math.Abs ( difference ) <= 1e-12 * current	{	break	}
+
+			if	difference > 0	{	high	= offset	} else	{	low	= offset	}
+
+			var speed	= vector_length ( Bezier_point ( & derivative, offset ) )
+
+			if	next := offset - difference / speed ; speed > 0	&& low < next && next < high	{
+				offset	= next
+			} else	{
+				offset	= ( low + high ) / 2.0
+			}
+		}
+		return	Bezier_point ( & self.Segments [ i ], offset )
+	}
+	return	nil
+}
+
+
+/*	Highest continuity at the joint of segments joint and joint +1
+
+	Points and derivatives are compared within tolerance, directions within tolerance of the unit tangents
+*/
+func ( self  * Path )	Continuity ( joint  int, tolerance  float64 )		( result  Continuity )	{
+
+	if	joint < 0	|| joint +1 >= len ( self.Segments )	{	return	Continuity_none	}
+
+	var (
+		current, next	= self.Segments [ joint ], self.Segments [ joint +1 ]
+		end_tangent		= segment_end_derivative ( current )
+		start_tangent	= segment_start_derivative ( next )
+	)
+
+	if	points_distance ( current [ len ( current ) -1 ], next [ 0 ] ) > tolerance	{	return	Continuity_none	}
+
+	result	= Continuity_C0
+
+	var (
+		end_length, start_length	= vector_length ( end_tangent ), vector_length ( start_tangent )
+	)
+
+	if	end_length == 0	|| start_length == 0	{	return	}
+
+	for	di := range	end_tangent	{
+		if	math.Abs ( end_tangent [ di ] / end_length - start_tangent [ di ] / start_length ) > tolerance	{	return	}
+	}
+	result	= Continuity_G1
+
+	if	points_distance ( end_tangent, start_tangent ) <= tolerance	{	result	= Continuity_C1	}
+
+	return
+}
+
+/*	Moves control points next to every joint, so the path has at least the given continuity
+
+	C0	: the start point of a segment is moved to the end of the previous one ( with its neighbour control point, so the start tangent is kept )
+	G1	: the second control point of a segment is moved onto the end tangent of the previous segment, keeping its distance
+	C1	: same, but the distance is chosen to match the derivatives : n_next * ( Q1 - Q0 ) == n_prev * ( Pn - Pn-1 )
+
+	Only inner control points are moved for G1 and C1, so end points of the segments stay : if the next segment is a line,
+	the control point before the end of the previous segment is moved onto the line direction instead.
+	A joint of two lines keeps its corner, segments of a single point have no tangent and are only moved ( C0 )
+*/
+func ( self  * Path )	Enforce_continuity ( level  Continuity )	{
+
+	for	joint := 0 ; joint +1 < len ( self.Segments ) ; joint ++	{
+
+		var (
+			current, next	= self.Segments [ joint ], self.Segments [ joint +1 ]
+			end		= current [ len ( current ) -1 ]
+		)
+
+		if	level >= Continuity_C0	{
+
+			var shift	= make ( [] float64, len ( end ) )
+
+			for	di := range	end	{	shift [ di ]	= end [ di ] - next [ 0 ][ di ]	}
+
+			next [ 0 ]	= append ( [] float64 ( nil ), end... )
+
+			if	len ( next ) > 2	{	next [ 1 ]	= add_scaled ( next [ 1 ], shift, 1.0 )	}
+		}
+
+		if	level < Continuity_G1	|| len ( current ) < 2	|| len ( next ) < 2	{	continue	}
+
+		if	len ( next ) > 2	{
+			next [ 1 ]	= tangent_control_point ( next [ 0 ], next [ 1 ], segment_end_derivative ( current ), len ( next ) -1, level )
+
+		} else	if	last := len ( current ) -1 ; last > 2	|| last == 2 && joint == 0	{
+
+//			Derivative of the reversed line ( P1 of a quadratic belongs to the previous joint, so it is moved only at the path start )
+			current [ last -1 ]	= tangent_control_point ( end, current [ last -1 ], add_scaled ( make ( [] float64, len ( end ) ), segment_start_derivative ( next ), -1.0 ), last, level )
+		}
+	}
+}
+
+/*	Control point next to the joint of a segment of the degree, which lies on the other segment's derivative direction :
+	at the same distance from the joint for G1, at the distance | derivative | / degree for C1
+
+	The control point is kept if the derivative is zero
+*/
+func tangent_control_point ( joint, control_point, derivative  [] float64, degree  int, level  Continuity )		[] float64	{
+
+	var (
+		length		= vector_length ( derivative )
+		distance	= points_distance ( joint, control_point )
+	)
+
+	if	length == 0	{	return	control_point	}
+
+	if	level >= Continuity_C1	{	distance	= length / float64 ( degree )	}
+
+	return	add_scaled ( joint, derivative, distance / length )
+}
+
+/*	Reverses the direction of the path : segments order and control points of each segment
+
+	Point ( offset ) of the reversed path is Point ( 1 - offset ) of the original one
+*/
+func ( self  * Path )	Reverse ()	{
+
+	var segments	= self.Segments
+
+	for	i, j := 0, len ( segments ) -1 ; i < j ; i, j = i +1, j -1	{
+		segments [ i ], segments [ j ]	= segments [ j ], segments [ i ]
+	}
+
+	for	_, segment := range	segments	{
+		for	i, j := 0, len ( segment ) -1 ; i < j ; i, j = i +1, j -1	{
+			segment [ i ], segment [ j ]	= segment [ j ], segment [ i ]
+		}
+	}
+}
+
+
+//	Arc length of the segment part [ 0, offset ], composite Gauss-Legendre quadrature of | B'( t ) |
+func segment_length ( segment  * [][] float64, offset  float64 )		( length  float64 )	{
+
+	const	intervals	= 16
+
+	var (
+		derivative	= Bezier_derivative ( segment )
+		width		= offset / intervals
+	)
+
+	if	derivative == nil	{	return	0.0	}
+
+	for	interval := 0 ; interval < intervals ; interval ++	{
+
+		var center	= ( float64 ( interval ) + 0.5 ) * width
+
+		for	i, node := range	gauss_nodes	{
+			length	+= gauss_weights [ i ] * width / 2.0 * vector_length ( Bezier_point ( & derivative, center + node * width / 2.0 ) )
+		}
+	}
+	return
+}
+
+//	Derivatives at the segment ends : n * ( P1 - P0 ) and n * ( Pn - Pn-1 )
+func segment_start_derivative ( segment  [][] float64 )		[] float64	{
+
+	if	len ( segment ) < 2	{	return	make ( [] float64, len ( segment [ 0 ] ) )	}
+
+	return	add_scaled (
+		make ( [] float64, len ( segment [ 0 ] ) ),
+		add_scaled ( segment [ 1 ], segment [ 0 ], -1.0 ),
+		float64 ( len ( segment ) -1 ),
+	)
+}
+
+func segment_end_derivative ( segment  [][] float64 )		[] float64	{
+
+	var last	= len ( segment ) -1
+
+	if	last < 1	{	return	make ( [] float64, len ( segment [ 0 ] ) )	}
+
+	return	add_scaled (
+		make ( [] float64, len ( segment [ 0 ] ) ),
+		add_scaled ( segment [ last ], segment [ last -1 ], -1.0 ),
+		float64 ( last ),
+	)
+}
+
+func vector_length ( vector  [] float64 )		float64	{
+
+	return	points_distance ( vector, make ( [] float64, len ( vector ) ) )
+}
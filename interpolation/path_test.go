@@ -0,0 +1,155 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"testing"
+)
+
+
+func Test_Path_point ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var path	Path
+
+	if	path.Point ( 0.5 ) != nil	|| path.Point_at_length ( 1.0 ) != nil	{
+		t.Error ( "Empty path should have no points" )
+	}
+
+//	Straight segments with uneven control points : ( 0, 0 ) -> ( 3, 0 ) -> ( 3, 4 )
+	if	err := path.Append ( [][] float64 { { 0.0, 0.0 }, { 2.5, 0.0 }, { 2.9, 0.0 }, { 3.0, 0.0 } } ) ; err != nil	{
+		t.Error ( err )
+	}
+	if	err := path.Append ( [][] float64 { { 3.0, 0.0 }, { 3.0, 4.0 } } ) ; err != nil	{
+		t.Error ( err )
+	}
+	if	err := path.Append ( [][] float64 { { 3.0, 0.0, 1.0 } } ) ; err == nil	{
+		t.Error ( "Dimensions differ but there is no error" )
+	}
+
+	var cases	= [...] struct	{
+		offset, length	float64
+		at_offset, at_length	[] float64
+	}	{
+		{	0.00, 0.0,	[] float64 { 0.0, 0.0 },	[] float64 { 0.0, 0.0 }	},
+		{	0.25, 1.5,	Bezier_point ( & path.Segments [ 0 ], 0.5 ),	[] float64 { 1.5, 0.0 }	},
+		{	0.50, 3.0,	[] float64 { 3.0, 0.0 },	[] float64 { 3.0, 0.0 }	},
+		{	0.75, 5.0,	[] float64 { 3.0, 2.0 },	[] float64 { 3.0, 2.0 }	},
+		{	1.00, 7.0,	[] float64 { 3.0, 4.0 },	[] float64 { 3.0, 4.0 }	},
+		{	2.00, 9.0,	[] float64 { 3.0, 4.0 },	[] float64 { 3.0, 4.0 }	},
+	}
+
+	if	fmt.Sprintf ( "%.6f", path.Length () ) != "7.000000"	{
+		t.Errorf ( "Path length expected = 7, got : %v", path.Length () )
+	}
+
+	for	_, c := range	cases	{
+
+		if	fmt.Sprintf ( "%.6f", path.Point ( c.offset ) ) != fmt.Sprintf ( "%.6f", c.at_offset )	{
+			t.Errorf ( "Offset %.2f : expected %v, got : %v", c.offset, c.at_offset, path.Point ( c.offset ) )
+		}
+
+		if	fmt.Sprintf ( "%.6f", path.Point_at_length ( c.length ) ) != fmt.Sprintf ( "%.6f", c.at_length )	{
+			t.Errorf ( "Length %.2f : expected %v, got : %v", c.length, c.at_length, path.Point_at_length ( c.length ) )
+		}
+	}
+}
+
+func Test_Path_continuity ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		path	= Path { Segments : [][][] float64 {
+			{ { 0.0, 0.0 }, { 1.0, 1.0 }, { 2.0, 1.0 }, { 3.0, 0.0 } },
+			{ { 3.1, 0.0 }, { 4.0, 0.0 }, { 5.0, 1.0 } },
+			{ { 5.0, 1.0 }, { 5.5, 1.5 }, { 6.0, 1.0 }, { 7.0, 0.0 } },
+		} }
+		tolerance	= 1e-9
+	)
+
+	if	c := path.Continuity ( 0, tolerance ) ; c != Continuity_none	{
+		t.Errorf ( "Joint 0 : expected %v, got : %v", Continuity_none, c )
+	}
+	if	c := path.Continuity ( 1, tolerance ) ; c != Continuity_G1	{
+		t.Errorf ( "Joint 1 : expected %v, got : %v", Continuity_G1, c )
+	}
+	if	c := path.Continuity ( 2, tolerance ) ; c != Continuity_none	{
+		t.Errorf ( "Joint out of range : expected %v, got : %v", Continuity_none, c )
+	}
+
+	for	_, level := range	[] Continuity { Continuity_C0, Continuity_G1, Continuity_C1 }	{
+
+		path.Enforce_continuity ( level )
+
+		for	joint := 0 ; joint < 2 ; joint ++	{
+
+			if	c := path.Continuity ( joint, tolerance ) ; c < level	{
+				t.Errorf ( "Joint %d after enforcing %v : got %v, %v", joint, level, c, path.Segments )
+			}
+		}
+	}
+
+//	Lines keep their end points, the cubic before the line turns to it
+	for	_, level := range	[] Continuity { Continuity_G1, Continuity_C1 }	{
+
+		var lines	= Path { Segments : [][][] float64 {
+			{ { 0.0, 0.0 }, { 1.0, 1.0 }, { 2.0, 1.0 }, { 3.0, 0.0 } },
+			{ { 3.0, 0.0 }, { 5.0, 0.0 } },
+			{ { 5.0, 0.0 }, { 5.0, 2.0 } },
+		} }
+
+		lines.Enforce_continuity ( level )
+
+		if	result := fmt.Sprint ( lines.Segments [ 1 : ] ) ; result != "[[[3 0] [5 0]] [[5 0] [5 2]]]"	{
+			t.Errorf ( "Lines after enforcing %v expected = [[[3 0] [5 0]] [[5 0] [5 2]]], got : %s", level, result )
+		}
+
+		if	c := lines.Continuity ( 0, tolerance ) ; c < level	{
+			t.Errorf ( "Joint of the cubic and line after enforcing %v : got %v, %v", level, c, lines.Segments [ 0 ] )
+		}
+
+		if	c := lines.Continuity ( 1, tolerance ) ; c != Continuity_C0	{
+			t.Errorf ( "Joint of two lines after enforcing %v expected = %v, got : %v", level, Continuity_C0, c )
+		}
+	}
+
+	var empty	Path
+
+	if	_, _, err := empty.Segment_offset ( 0.5 ) ; err == nil	{
+		t.Error ( "Path is empty but Segment_offset has no error" )
+	}
+
+	if	index, offset, err := path.Segment_offset ( 0.75 ) ; err != nil	|| index != 2	|| fmt.Sprintf ( "%.4f", offset ) != "0.2500"	{
+		t.Errorf ( "Segment_offset ( 0.75 ) expected = 2, 0.2500, got : %d, %.4f, %v", index, offset, err )
+	}
+}
+
+func Test_Path_reverse ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		path	= Path { Segments : [][][] float64 {
+			{ { 0.0, 0.0 }, { 1.0, 1.0 }, { 2.0, 1.0 }, { 3.0, 0.0 } },
+			{ { 3.0, 0.0 }, { 4.0, -1.0 }, { 5.0, 0.0 } },
+		} }
+		expected	[][] float64
+	)
+
+	for	i := 0 ; i <= 10 ; i ++	{
+		expected	= append ( expected, path.Point ( 1.0 - float64 ( i ) / 10.0 ) )
+	}
+
+	path.Reverse ()
+
+	for	i := 0 ; i <= 10 ; i ++	{
+
+		if	result := path.Point ( float64 ( i ) / 10.0 ) ; fmt.Sprintf ( "%.6f", result ) != fmt.Sprintf ( "%.6f", expected [ i ] )	{
+			t.Errorf ( "Offset %.2f : expected %v, got : %v", float64 ( i ) / 10.0, expected [ i ], result )
+		}
+	}
+}
@@ -0,0 +1,514 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/sjbog/math_tools"
+)
+
+
+//	---------------------------
+type  svg_path_error  struct {
+
+	Msg	string
+}
+
+func ( self  svg_path_error )	Error ()		string	{
+	return	self.Msg
+}
+
+func new_svg_path_error ( data  string, position  int )		error	{
+
+	if	position >= len ( data )	{
+		return	svg_path_error { "Error : SVG path data ends unexpectedly" }
+	}
+	return	svg_path_error { fmt.Sprintf ( "Error : SVG path data, unexpected %q at %d", data [ position ], position ) }
+}
+//	---------------------------
+
+//	Number of arguments of SVG path commands ( except arcs, which have flags )
+var svg_arguments	= map [ byte ] int { 'M' : 2, 'L' : 2, 'H' : 1, 'V' : 1, 'C' : 6, 'S' : 4, 'Q' : 4, 'T' : 2 }
+
+
+/*	Parses SVG path data ( "d" attribute ) into Bézier paths, one per subpath
+
+	Commands M, L, H, V, C, S, Q, T, A, Z are supported, both absolute ( upper case ) and relative ( lower case ).
+	Segments are control points for Bezier_point :
+
+		L, H, V	: line, 2 points
+		Q, T	: quadratic curve, 3 points
+		C, S	: cubic curve, 4 points
+		A		: elliptical arc, approximated by cubic curves within tolerance ( maximal distance from the arc )
+		Z		: line back to the subpath start ( if it is not there already ), Path.Closed is set
+
+	Example
+
+		Svg_path_parse ( "M 10 10 h 20 q 10 0 10 10 A 5 5 0 0 1 30 30 z", 0.01 )
+*/
+func Svg_path_parse ( data  string, tolerance  float64 )		( paths  [] Path, err  error )	{
+
+	if	! ( tolerance > 0 )	{	return	paths, math_tools.Arg_range_error ()	}
+
+	var (
+		scanner	= svg_scanner { data : data }
+
+		command	byte
+		current, start	[] float64
+//		Control point for S and T commands ( reflected ), nil if the previous segment is of another type
+		cubic_control, quadratic_control	[] float64
+
+		path	* Path
+	)
+
+//	Points are copied, so segments don't share the joints with each other or the parser state
+	var add_segment	= func ( segment  [][] float64 )	{
+
+		if	path == nil	{
+			paths	= append ( paths, Path {} )
+			path	= & paths [ len ( paths ) -1 ]
+			start	= current
+		}
+		path.Segments	= append ( path.Segments, copy_points ( segment ) )
+		current	= segment [ len ( segment ) -1 ]
+	}
+
+	for	{
+		scanner.skip_separators ()
+
+		if	scanner.end ()	{	break	}
+
+		if	next, ok := scanner.command () ; ok	{
+			command	= next
+		} else	if	command == 0	|| command == 'Z' || command == 'z'	{
+			return	nil, new_svg_path_error ( data, scanner.position )
+		}
+
+		var (
+//			Leading "m" is absolute
+			relative	= command >= 'a'	&& current != nil
+			values		[] float64
+			flags		[ 2 ] bool
+		)
+
+//		Coordinates of the argument pair i, relative commands are shifted by the current point
+		var point	= func ( i  int )	[] float64	{
+
+			if	relative	{	return	[] float64 { current [ 0 ] + values [ i ], current [ 1 ] + values [ i +1 ] }	}
+
+			return	[] float64 { values [ i ], values [ i +1 ] }
+		}
+
+		if	current == nil	&& command != 'M' && command != 'm'	{
+			return	nil, new_svg_path_error ( data, scanner.position -1 )
+		}
+
+		switch	command	{
+
+			case 'Z', 'z' :
+
+				if	path != nil	{
+
+					if	points_distance ( current, start ) > 0	{	add_segment ( [][] float64 { current, start } )	}
+
+					path.Closed	= true
+				}
+				path, current	= nil, start
+				cubic_control, quadratic_control	= nil, nil
+				continue
+
+			case 'A', 'a' :
+
+				if	values, err	= scanner.numbers ( 3 ) ; err == nil	{
+					if	flags [ 0 ], err	= scanner.flag () ; err == nil	{
+						if	flags [ 1 ], err	= scanner.flag () ; err == nil	{
+
+							var end	[] float64
+
+							if	end, err	= scanner.numbers ( 2 ) ; err == nil	{
+								values	= append ( values, end... )
+							}
+						}
+					}
+				}
+
+			default :
+
+				var count, known	= svg_arguments [ command &^ 0x20 ]
+
+				if	! known	{	return	nil, new_svg_path_error ( data, scanner.position -1 )	}
+
+				values, err	= scanner.numbers ( count )
+		}
+
+		if	err != nil	{	return	nil, err	}
+
+		var	next_cubic, next_quadratic	[] float64
+
+		switch	command &^ 0x20	{
+
+			case 'M' :
+				path, current	= nil, point ( 0 )
+				start	= current
+
+//				Following pairs are lines, relative after "m" even if it is leading
+				if	command == 'm'	{	command	= 'l'	} else	{	command	= 'L'	}
+
+			case 'L' :
+				add_segment ( [][] float64 { current, point ( 0 ) } )
+
+			case 'H' :
+				var x	= values [ 0 ]
+				if	relative	{	x	+= current [ 0 ]	}
+				add_segment ( [][] float64 { current, { x, current [ 1 ] } } )
+
+			case 'V' :
+				var y	= values [ 0 ]
+				if	relative	{	y	+= current [ 1 ]	}
+				add_segment ( [][] float64 { current, { current [ 0 ], y } } )
+
+			case 'C' :
+				next_cubic	= point ( 2 )
+				add_segment ( [][] float64 { current, point ( 0 ), next_cubic, point ( 4 ) } )
+
+			case 'S' :
+				var first	= reflect_control ( current, cubic_control )
+				next_cubic	= point ( 0 )
+				add_segment ( [][] float64 { current, first, next_cubic, point ( 2 ) } )
+
+			case 'Q' :
+				next_quadratic	= point ( 0 )
+				add_segment ( [][] float64 { current, next_quadratic, point ( 2 ) } )
+
+			case 'T' :
+				next_quadratic	= reflect_control ( current, quadratic_control )
+				add_segment ( [][] float64 { current, next_quadratic, point ( 0 ) } )
+
+			case 'A' :
+				for	_, segment := range	svg_arc ( current, point ( 3 ), values [ 0 ], values [ 1 ], values [ 2 ], flags [ 0 ], flags [ 1 ], tolerance )	{
+					add_segment ( segment )
+				}
+		}
+		cubic_control, quadratic_control	= next_cubic, next_quadratic
+	}
+
+	return	paths, nil
+}
+
+
+/*	Serialises paths to compact SVG path data with absolute commands
+
+	Lines are written as L ( or H, V ), quadratic curves as Q and cubic as C, single points are skipped.
+	Numbers are rounded to the precision ( digits after the point ), trailing zeros and separators are omitted where possible.
+
+	Returns an error for curves of a higher degree ( see Bezier_reduce_degree_within ) or not 2-D points
+*/
+func Svg_path_data ( paths  [] Path, precision  int )		( data  string, err  error )	{
+
+	var (
+		builder	strings.Builder
+		last_command	byte
+		current	[] float64
+	)
+
+	var write	= func ( command  byte, values  ... float64 )	{
+
+		var repeated	= command == last_command	&& command != 'M'
+
+		if	! repeated	{
+			builder.WriteByte ( command )
+			last_command	= command
+		}
+
+		for	i, value := range	values	{
+
+			var number	= svg_number ( value, precision )
+
+			if	( i > 0 || repeated )	&& number [ 0 ] != '-'	{	builder.WriteByte ( ' ' )	}
+
+			builder.WriteString ( number )
+		}
+	}
+
+	for	_, path := range	paths	{
+
+		for	i, segment := range	path.Segments	{
+
+			if	len ( segment [ 0 ] ) != 2	{	return	"", math_tools.Arg_range_error ()	}
+
+			if	i == 0	|| svg_number ( segment [ 0 ][ 0 ], precision ) != svg_number ( current [ 0 ], precision )	||
+				svg_number ( segment [ 0 ][ 1 ], precision ) != svg_number ( current [ 1 ], precision )	{
+
+				write ( 'M', segment [ 0 ]... )
+			}
+
+			current	= segment [ len ( segment ) -1 ]
+
+			switch	len ( segment )	{
+
+				case 1 :
+
+				case 2 :
+					if	path.Closed	&& i == len ( path.Segments ) -1	&& points_distance ( current, path.Segments [ 0 ][ 0 ] ) == 0	{
+						continue
+					}
+
+					switch	{
+						case svg_number ( segment [ 0 ][ 1 ], precision ) == svg_number ( current [ 1 ], precision ) :
+							write ( 'H', current [ 0 ] )
+
+						case svg_number ( segment [ 0 ][ 0 ], precision ) == svg_number ( current [ 0 ], precision ) :
+							write ( 'V', current [ 1 ] )
+
+						default :
+							write ( 'L', current... )
+					}
+
+				case 3 :
+					write ( 'Q', segment [ 1 ][ 0 ], segment [ 1 ][ 1 ], current [ 0 ], current [ 1 ] )
+
+				case 4 :
+					write ( 'C', segment [ 1 ][ 0 ], segment [ 1 ][ 1 ], segment [ 2 ][ 0 ], segment [ 2 ][ 1 ], current [ 0 ], current [ 1 ] )
+
+				default :
+					return	"", math_tools.Arg_range_error ()
+			}
+		}
+
+		if	path.Closed	&& len ( path.Segments ) > 0	{
+
+			builder.WriteByte ( 'Z' )
+			last_command, current	= 'Z', path.Segments [ 0 ][ 0 ]
+		}
+	}
+
+	return	builder.String (), nil
+}
+
+
+/*	Converts an SVG elliptical arc into cubic curves ( SVG 1.1 implementation notes, F.6.5 and F.6.6 )
+
+	Every cubic approximates an arc of the unit circle of angle θ ( scaled by the ellipse radii ),
+	its maximal radial error is at most
+
+		max ( rx, ry ) * 4/27 * sin^6( θ / 4 ) / cos^2( θ / 4 )
+
+	so the arc is split in equal parts until the error is within tolerance
+*/
+func svg_arc ( from, to  [] float64, rx, ry, rotation  float64, large_arc, sweep  bool, tolerance  float64 )		( segments  [][][] float64 )	{
+
+	if	points_distance ( from, to ) == 0	{	return	nil	}
+
+	rx, ry	= math.Abs ( rx ), math.Abs ( ry )
+
+	if	rx == 0	|| ry == 0	{	return	[][][] float64 { { from, to } }	}
+
+	var (
+		sin_phi, cos_phi	= math.Sincos ( rotation * math.Pi / 180.0 )
+
+//		Middle point in the ellipse coordinates
+		dx, dy	= ( from [ 0 ] - to [ 0 ] ) / 2.0, ( from [ 1 ] - to [ 1 ] ) / 2.0
+		x1		= cos_phi * dx + sin_phi * dy
+		y1		= -sin_phi * dx + cos_phi * dy
+	)
+
+//	Radii are scaled up if they are too small to reach the end point
+	if	scale := x1 * x1 / ( rx * rx ) + y1 * y1 / ( ry * ry ) ; scale > 1	{
+		rx, ry	= rx * math.Sqrt ( scale ), ry * math.Sqrt ( scale )
+	}
+
+	var (
+		numerator	= rx * rx * ry * ry - rx * rx * y1 * y1 - ry * ry * x1 * x1
+		coefficient	= math.Sqrt ( math.Max ( 0, numerator / ( rx * rx * y1 * y1 + ry * ry * x1 * x1 ) ) )
+	)
+
+	if	large_arc == sweep	{	coefficient	= -coefficient	}
+
+	var (
+		cx1, cy1	= coefficient * rx * y1 / ry, -coefficient * ry * x1 / rx
+		cx	= cos_phi * cx1 - sin_phi * cy1 + ( from [ 0 ] + to [ 0 ] ) / 2.0
+		cy	= sin_phi * cx1 + cos_phi * cy1 + ( from [ 1 ] + to [ 1 ] ) / 2.0
+
+		start_angle	= math.Atan2 ( ( y1 - cy1 ) / ry, ( x1 - cx1 ) / rx )
+		delta		= math.Atan2 ( ( -y1 - cy1 ) / ry, ( -x1 - cx1 ) / rx ) - start_angle
+	)
+
+	if	sweep	&& delta < 0	{	delta	+= 2 * math.Pi	}
+	if	! sweep	&& delta > 0	{	delta	-= 2 * math.Pi	}
+
+	var parts	= int ( math.Ceil ( math.Abs ( delta ) / ( math.Pi / 2.0 ) - 1e-9 ) )
+
+	for	;	; parts ++	{
+
+		var (
+			quarter		= math.Abs ( delta ) / float64 ( parts ) / 4.0
+			arc_error	= math.Max ( rx, ry ) * 4.0 / 27.0 * math.Pow ( math.Sin ( quarter ), 6 ) / ( math.Cos ( quarter ) * math.Cos ( quarter ) )
+		)
+
+		if	arc_error <= tolerance	|| parts >= 1024	{	break	}
+	}
+
+	var (
+		step	= delta / float64 ( parts )
+		handle	= 4.0 / 3.0 * math.Tan ( step / 4.0 )
+
+//		Unit circle point to the ellipse
+		transform	= func ( x, y  float64 )	[] float64	{
+			return	[] float64 {
+				cx + cos_phi * rx * x - sin_phi * ry * y,
+				cy + sin_phi * rx * x + cos_phi * ry * y,
+			}
+		}
+		point	= from
+	)
+
+	for	part := 1 ; part <= parts ; part ++	{
+
+		var (
+			sin_start, cos_start	= math.Sincos ( start_angle + step * float64 ( part -1 ) )
+			sin_end, cos_end		= math.Sincos ( start_angle + step * float64 ( part ) )
+			end	= transform ( cos_end, sin_end )
+		)
+
+		if	part == parts	{	end	= to	}
+
+		segments	= append ( segments, [][] float64 {
+			point,
+			transform ( cos_start - handle * sin_start, sin_start + handle * cos_start ),
+			transform ( cos_end + handle * sin_end, sin_end - handle * cos_end ),
+			end,
+		})
+		point	= end
+	}
+	return
+}
+
+//	Control point reflected about the current point, or the current point itself
+func reflect_control ( current, control  [] float64 )		[] float64	{
+
+	if	control == nil	{	return	current	}
+
+	return	[] float64 { 2 * current [ 0 ] - control [ 0 ], 2 * current [ 1 ] - control [ 1 ] }
+}
+
+//	Shortest form of a number : no trailing zeros, no leading zero before the point
+func svg_number ( value  float64, precision  int )		string	{
+
+	var number	= strconv.FormatFloat ( value, 'f', precision, 64 )
+
+	if	strings.Contains ( number, "." )	{
+		number	= strings.TrimRight ( strings.TrimRight ( number, "0" ), "." )
+	}
+
+	switch	{
+		case number == "-0" :	return	"0"
+		case strings.HasPrefix ( number, "0." ) :	return	number [ 1 : ]
+		case strings.HasPrefix ( number, "-0." ) :	return	"-" + number [ 2 : ]
+	}
+	return	number
+}
+
+
+type svg_scanner struct {
+
+	data		string
+	position	int
+}
+
+func ( self  * svg_scanner )	end ()		bool	{
+	return	self.position >= len ( self.data )
+}
+
+func ( self  * svg_scanner )	skip_separators ()	{
+
+	for	! self.end ()	&& strings.IndexByte ( " \t\r\n\f,", self.data [ self.position ] ) >= 0	{
+		self.position ++
+	}
+}
+
+//	Reads a command letter, if the next symbol is one
+func ( self  * svg_scanner )	command ()		( byte, bool )	{
+
+	var symbol	= self.data [ self.position ]
+
+	if	( symbol | 0x20 ) >= 'a'	&& ( symbol | 0x20 ) <= 'z'	&& ( symbol | 0x20 ) != 'e'	{
+		self.position ++
+		return	symbol, true
+	}
+	return	0, false
+}
+
+func ( self  * svg_scanner )	numbers ( count  int )		( values  [] float64, err  error )	{
+
+	values	= make ( [] float64, count )
+
+	for	i := range	values	{
+		if	values [ i ], err	= self.number () ; err != nil	{	return	nil, err	}
+	}
+	return
+}
+
+//	Reads a number : [ sign ] digits [ . digits ] [ e [ sign ] digits ], "1.5.5" is two numbers
+func ( self  * svg_scanner )	number ()		( float64, error )	{
+
+	self.skip_separators ()
+
+	var (
+		start	= self.position
+		digits	= 0
+		data	= self.data
+	)
+
+	var skip_digits	= func ()	{
+		for	! self.end ()	&& data [ self.position ] >= '0' && data [ self.position ] <= '9'	{
+			self.position ++
+			digits ++
+		}
+	}
+
+	if	! self.end ()	&& ( data [ self.position ] == '-' || data [ self.position ] == '+' )	{	self.position ++	}
+
+	skip_digits ()
+
+	if	! self.end ()	&& data [ self.position ] == '.'	{
+		self.position ++
+		skip_digits ()
+	}
+
+	if	digits == 0	{	return	0, new_svg_path_error ( data, start )	}
+
+	if	! self.end ()	&& ( data [ self.position ] | 0x20 ) == 'e'	{
+
+		var (
+			mantissa_end	= self.position
+			exponent_digits	= digits
+		)
+		self.position ++
+
+		if	! self.end ()	&& ( data [ self.position ] == '-' || data [ self.position ] == '+' )	{	self.position ++	}
+
+		skip_digits ()
+
+		if	digits == exponent_digits	{	self.position	= mantissa_end	}
+	}
+
+	return	strconv.ParseFloat ( data [ start : self.position ], 64 )
+}
+
+//	Reads an arc flag, which might not be separated from the next value : "a1 1 0 01 1 1"
+func ( self  * svg_scanner )	flag ()		( bool, error )	{
+
+	self.skip_separators ()
+
+	if	self.end ()	|| self.data [ self.position ] != '0' && self.data [ self.position ] != '1'	{
+		return	false, new_svg_path_error ( self.data, self.position )
+	}
+
+	self.position ++
+	return	self.data [ self.position -1 ] == '1', nil
+}
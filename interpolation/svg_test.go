@@ -0,0 +1,173 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+func Test_Svg_path_parse ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [...] struct	{
+		data		string
+		expected	string
+	}	{
+		{	"M 10 10 L 20 10 H 30 V 20 Z",
+			"[[[[10 10] [20 10]] [[20 10] [30 10]] [[30 10] [30 20]] [[30 20] [10 10]]] true]",
+		},
+		{	"m10,10 l10,0 h10 v10 z",
+			"[[[[10 10] [20 10]] [[20 10] [30 10]] [[30 10] [30 20]] [[30 20] [10 10]]] true]",
+		},
+//		Implicit lines after a leading m are relative
+		{	"m 10 10 20 20",
+			"[[[[10 10] [30 30]]] false]",
+		},
+//		Implicit lines after M, compact numbers
+		{	"M0-1.5.5 10e-1,1 2",
+			"[[[[0 -1.5] [0.5 1]] [[0.5 1] [1 2]]] false]",
+		},
+		{	"M0 0C1 2 3 2 4 0S7 -2 8 0",
+			"[[[[0 0] [1 2] [3 2] [4 0]] [[4 0] [5 -2] [7 -2] [8 0]]] false]",
+		},
+		{	"M0 0q1 2 2 0t2 0",
+			"[[[[0 0] [1 2] [2 0]] [[2 0] [3 -2] [4 0]]] false]",
+		},
+//		S without a previous cubic uses the current point
+		{	"M0 0 S 1 1 2 0",
+			"[[[[0 0] [0 0] [1 1] [2 0]]] false]",
+		},
+//		Two subpaths, the second starts at the closed one's start
+		{	"M 0 0 L 1 0 z l 0 1 M 5 5 L 6 6",
+			"[[[[0 0] [1 0]] [[1 0] [0 0]]] true [[[0 0] [0 1]]] false [[[5 5] [6 6]]] false]",
+		},
+//		Zero radius arc is a line
+		{	"M 0 0 A 0 5 0 0 1 10 0",
+			"[[[[0 0] [10 0]]] false]",
+		},
+	}
+
+	for	_, c := range	cases	{
+
+		var paths, err	= Svg_path_parse ( c.data, 0.01 )
+
+		if	result := format_paths ( paths ) ; err != nil	|| result != c.expected	{
+			t.Errorf ( "%q : expected %v, got : %v ( %v )", c.data, c.expected, result, err )
+		}
+	}
+
+	for	_, data := range	[] string { "L 1 1", "M 1", "M 1 1 X", "M 0 0 A 1 1 0 2 0 1 1", "M 0 0 z 1 1", "M 1 1 L 1 e" }	{
+
+		if	paths, err := Svg_path_parse ( data, 0.01 ) ; err == nil	{
+			t.Errorf ( "%q is wrong but there is no error, result : %v", data, format_paths ( paths ) )
+		}
+	}
+
+//	Segments don't share points : changing one doesn't change the next one or the closing one
+	var paths, err	= Svg_path_parse ( "M 0 0 L 1 0 L 1 1 z", 0.01 )
+
+	if	err != nil	{	t.Fatal ( err )	}
+
+	paths [ 0 ].Segments [ 0 ][ 0 ][ 0 ], paths [ 0 ].Segments [ 0 ][ 1 ][ 0 ]	= 100, 100
+
+	if	result, expected := format_paths ( paths ), "[[[[100 0] [100 0]] [[1 0] [1 1]] [[1 1] [0 0]]] true]" ; result != expected	{
+		t.Errorf ( "Mutated segment expected = %v, got : %v", expected, result )
+	}
+}
+
+func Test_Svg_arc ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [...] struct	{
+		data	string
+		center	[] float64
+		rx, ry	float64
+		parts	int
+	}	{
+//		Half of the unit circle, packed flags
+		{	"M 1 0 A 1 1 0 01-1 0",		[] float64 { 0.0, 0.0 },	1.0, 1.0,	2	},
+//		Radii are too small and are scaled up to 5
+		{	"M 0 0 a 1 1 0 1 0 10 0",	[] float64 { 5.0, 0.0 },	5.0, 5.0,	2	},
+//		Large arc of an ellipse
+		{	"M 20 10 A 20 10 0 1 1 0 0",	[] float64 { 0.0, 10.0 },	20.0, 10.0,	3	},
+	}
+
+	for	_, tolerance := range	[] float64 { 0.01, 1e-5 }	{
+		for	_, c := range	cases	{
+
+			var paths, err	= Svg_path_parse ( c.data, tolerance )
+
+			if	err != nil	|| len ( paths ) != 1	|| len ( paths [ 0 ].Segments ) < c.parts	{
+				t.Errorf ( "%q : expected at least %d cubics, got : %v ( %v )", c.data, c.parts, format_paths ( paths ), err )
+				continue
+			}
+
+			for	_, segment := range	paths [ 0 ].Segments	{
+				for	i := 0 ; i <= 20 ; i ++	{
+
+					var (
+						point	= Bezier_point ( & segment, float64 ( i ) / 20.0 )
+						x, y	= ( point [ 0 ] - c.center [ 0 ] ) / c.rx, ( point [ 1 ] - c.center [ 1 ] ) / c.ry
+					)
+
+//					Radial error of the unit circle, scaled back
+					if	distance := math.Abs ( math.Hypot ( x, y ) - 1.0 ) * math.Min ( c.rx, c.ry ) ; distance > tolerance	{
+						t.Errorf ( "%q : point %v is %v away from the arc", c.data, point, distance )
+						break
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_Svg_path_data ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var cases	= [...] struct	{
+		data, expected	string
+	}	{
+		{	"M 10 10 L 20 10 L 30 10 V 20 L 25 25 Z",	"M10 10H20 30V20L25 25Z"	},
+		{	"M0 0C1 2 3 2 4 0S7 -2 8 0",	"M0 0C1 2 3 2 4 0 5-2 7-2 8 0"	},
+		{	"M 0.5 -0.25 Q 1 1 2 0 M 5 5 L 6 6",	"M.5-.25Q1 1 2 0M5 5L6 6"	},
+	}
+
+	for	_, c := range	cases	{
+
+		var paths, _	= Svg_path_parse ( c.data, 0.01 )
+
+		var result, err	= Svg_path_data ( paths, 3 )
+
+		if	err != nil	|| result != c.expected	{
+			t.Errorf ( "%q : expected %q, got : %q ( %v )", c.data, c.expected, result, err )
+			continue
+		}
+
+//		Round trip
+		if	parsed, err := Svg_path_parse ( result, 0.01 ) ; err != nil	|| format_paths ( parsed ) != format_paths ( paths )	{
+			t.Errorf ( "%q : parsed back %v, expected %v ( %v )", result, format_paths ( parsed ), format_paths ( paths ), err )
+		}
+	}
+
+	if	_, err := Svg_path_data ( [] Path { { Segments : [][][] float64 { Bezier_elevate_degree ( & [][] float64 { { 0, 0 }, { 1, 1 }, { 2, 0 }, { 3, 1 } }, 1 ) } } }, 3 ) ; err == nil	{
+		t.Error ( "Quartic curve can't be written but there is no error" )
+	}
+}
+
+func format_paths ( paths  [] Path )		( result  string )	{
+
+	for	i, path := range	paths	{
+
+		if	i > 0	{	result	+= " "	}
+
+		result	+= fmt.Sprint ( path.Segments, path.Closed )
+	}
+	return	"[" + result + "]"
+}
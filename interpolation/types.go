@@ -0,0 +1,226 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation	;	import	( "github.com/sjbog/math_tools" )
+
+/*	Floating point types of the generic functions ( Bezier_point_generic, Bernstein_basis_generic, Akima_interval_curve_generic )
+
+	float64 functions are their instantiations, float32 ones compute powers by math.Pow in float64 and round the result
+*/
+type Float interface {
+	~float32 | ~float64
+}
+
+//	Point of a plane : { x, y }
+type Point2	[ 2 ] float64
+
+//	Point of a space : { x, y, z }
+type Point3	[ 3 ] float64
+
+//	Point or vector of any dimensions
+type VecN	[] float64
+
+func ( self  Point2 )	Vec ()		VecN	{	return	VecN { self [ 0 ], self [ 1 ] }	}
+func ( self  Point3 )	Vec ()		VecN	{	return	VecN { self [ 0 ], self [ 1 ], self [ 2 ] }	}
+
+//	----------------------------------------
+
+/*	Control points of a Bézier curve, validated once when built
+
+	Every point has the same ( positive ) number of dimensions, the points are copied so later changes of the input don't affect the curve
+*/
+type Bezier_curve struct {
+	points	[][] float64
+}
+
+//	Return error if there are no points, a point has no dimensions or dimensions differ
+func New_bezier_curve ( control_points  [] VecN )		( curve  Bezier_curve, err  error )	{
+
+	if	len ( control_points ) == 0	|| len ( control_points [ 0 ] ) == 0	{
+		return	curve, math_tools.Arg_range_error ()
+	}
+
+	curve.points	= make ( [][] float64, len ( control_points ) )
+
+	for	i, point := range	control_points	{
+
+		if	len ( point ) != len ( control_points [ 0 ] )	{
+			return	Bezier_curve {}, math_tools.Arg_range_error ()
+		}
+		curve.points [ i ]	= append ( [] float64 ( nil ), point... )
+	}
+	return
+}
+
+//	Plane curve, return error if there are no points
+func New_bezier_curve2 ( control_points  ... Point2 )		( Bezier_curve, error )	{
+
+	var points	= make ( [] VecN, len ( control_points ) )
+
+	for	i, point := range	control_points	{	points [ i ]	= point.Vec ()	}
+
+	return	New_bezier_curve ( points )
+}
+
+//	Space curve, return error if there are no points
+func New_bezier_curve3 ( control_points  ... Point3 )		( Bezier_curve, error )	{
+
+	var points	= make ( [] VecN, len ( control_points ) )
+
+	for	i, point := range	control_points	{	points [ i ]	= point.Vec ()	}
+
+	return	New_bezier_curve ( points )
+}
+
+//	Degree of the curve : number of control points -1 ( 0 for an empty curve )
+func ( self  Bezier_curve )	Degree ()		uint	{
+
+	if	len ( self.points ) == 0	{	return	0	}
+
+	return	uint ( len ( self.points ) -1 )
+}
+
+func ( self  Bezier_curve )	Dimensions ()		int	{
+
+	if	len ( self.points ) == 0	{	return	0	}
+
+	return	len ( self.points [ 0 ] )
+}
+
+//	Copy of the control points in the format of Bezier_point
+func ( self  Bezier_curve )	Control_points ()		[][] float64	{
+
+	return	copy_points ( self.points )
+}
+
+//	See Bezier_point
+func ( self  Bezier_curve )	Point ( offset  float64 )		VecN	{
+
+	return	Bezier_point_to ( & self.points, offset, nil )
+}
+
+//	See Bezier_point_to
+func ( self  Bezier_curve )	Point_to ( offset  float64, destination  VecN )		VecN	{
+
+	return	Bezier_point_to ( & self.points, offset, destination )
+}
+
+//	Point of a plane curve, return error if the curve has other dimensions
+func ( self  Bezier_curve )	Point2 ( offset  float64 )		( result  Point2, err  error )	{
+
+	if	self.Dimensions () != 2	{	return	result, math_tools.Arg_range_error ()	}
+
+	Bezier_point_to ( & self.points, offset, result [ : ] )
+	return
+}
+
+//	Point of a space curve, return error if the curve has other dimensions
+func ( self  Bezier_curve )	Point3 ( offset  float64 )		( result  Point3, err  error )	{
+
+	if	self.Dimensions () != 3	{	return	result, math_tools.Arg_range_error ()	}
+
+	Bezier_point_to ( & self.points, offset, result [ : ] )
+	return
+}
+
+//	See Bezier_derivative, the derivative of a single point curve is a zero point
+func ( self  Bezier_curve )	Derivative ()		Bezier_curve	{
+
+	if	len ( self.points ) < 2	{
+		return	Bezier_curve { [][] float64 { make ( [] float64, self.Dimensions () ) } }
+	}
+	return	Bezier_curve { Bezier_derivative ( & self.points ) }
+}
+
+//	See Bezier_split
+func ( self  Bezier_curve )	Split ( offset  float64 )		( left, right  Bezier_curve )	{
+
+	var left_points, right_points	= Bezier_split ( & self.points, offset )
+
+	return	Bezier_curve { left_points }, Bezier_curve { right_points }
+}
+
+//	----------------------------------------
+
+/*	Data points for Akima interpolation, validated once when built
+
+	There are at least 5 points of at least 2 columns { x, y, ... }, sorted by strictly increasing x
+*/
+type Akima_data struct {
+	points	[][] float64
+}
+
+func New_akima_data ( data_points  [] Point2 )		( Akima_data, error )	{
+
+	var points	= make ( [][] float64, len ( data_points ) )
+
+	for	i, point := range	data_points	{	points [ i ]	= point.Vec ()	}
+
+	return	akima_data_of ( & points )
+}
+
+//	Validates the points in the format of Akima_interval_curve and copies them
+func Akima_data_from ( data_points  * [][] float64 )		( data  Akima_data, err  error )	{
+
+	if	data, err	= akima_data_of ( data_points ) ; err != nil	{	return	}
+
+	var points	= make ( [][] float64, len ( data.points ) )
+
+	for	i, point := range	data.points	{
+		points [ i ]	= append ( [] float64 ( nil ), point... )
+	}
+	return	Akima_data { points }, nil
+}
+
+//	Number of data points
+func ( self  Akima_data )	Len ()		int	{	return	len ( self.points )	}
+
+func ( self  Akima_data )	Point ( index  int )		Point2	{
+
+	return	Point2 { self.points [ index ][ 0 ], self.points [ index ][ 1 ] }
+}
+
+//	Interpolated y at x, see Akima_interval_curve
+func ( self  Akima_data )	Value ( x  float64 )		( y  float64, err  error )	{
+
+	var curve	* Akima_curve
+
+	if	curve, err	= self.Interval_curve ( x ) ; err != nil	{	return	}
+
+	return	curve.Point ( x ), nil
+}
+
+//	Validates the points without copying them
+func akima_data_of ( data_points  * [][] float64 )		( data  Akima_data, err  error )	{
+
+	if	! akima_points_valid ( data_points )	{	return	data, math_tools.Arg_range_error ()	}
+
+	return	Akima_data { * data_points }, nil
+}
+
+//	At least 5 points of at least 2 columns, sorted by strictly increasing x
+func akima_points_valid [ F  Float ] ( data_points  * [][] F )		bool	{
+
+	if	data_points == nil	|| len ( * data_points ) < 5	{	return	false	}
+
+	for	i, point := range	* data_points	{
+
+		if	len ( point ) < 2	||
+			i > 0 && ! ( point [ 0 ] > ( * data_points ) [ i -1 ][ 0 ] )	{
+
+			return	false
+		}
+	}
+	return	true
+}
+
+//	Deep copy : the points don't share memory with the source or each other
+func copy_points ( points  [][] float64 )		( result  [][] float64 )	{
+
+	result	= make ( [][] float64, len ( points ) )
+
+	for	i, point := range	points	{
+		result [ i ]	= append ( [] float64 ( nil ), point... )
+	}
+	return
+}
@@ -0,0 +1,141 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	interpolation
+
+import	(
+	"fmt"
+	"math"
+	"testing"
+)
+
+
+func Test_Bezier_curve ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		control_points	= [][] float64 { { 0.0, 0.0 }, { 0.0, 8.0 }, { 16.0, 8.0 }, { 16.0, 0.0 } }
+		curve, err		= New_bezier_curve2 ( Point2 { 0, 0 }, Point2 { 0, 8 }, Point2 { 16, 8 }, Point2 { 16, 0 } )
+	)
+
+	if	err != nil	{
+		t.Error ( err )
+		t.FailNow ()
+	}
+
+	if	curve.Degree () != 3	|| curve.Dimensions () != 2	{
+		t.Errorf ( "Degree and dimensions expected = 3, 2, got : %d, %d", curve.Degree (), curve.Dimensions () )
+	}
+
+	for	_, offset := range	[] float64 { 0.0, 0.3, 0.5, 1.0 }	{
+
+		var (
+			expected	= fmt.Sprint ( Bezier_point ( & control_points, offset ) )
+			point2, _	= curve.Point2 ( offset )
+		)
+
+		if	fmt.Sprint ( curve.Point ( offset ) ) != expected	|| fmt.Sprint ( point2.Vec () ) != expected	{
+			t.Errorf ( "Curve point at %.4f expected = %v, got : %v, %v", offset, expected, curve.Point ( offset ), point2 )
+		}
+	}
+
+	if	_, err := curve.Point3 ( 0.5 ) ; err == nil	{
+		t.Error ( "Plane curve has no space points but there is no error" )
+	}
+
+	var left, right	= curve.Split ( 0.5 )
+
+	if	fmt.Sprint ( left.Point ( 1.0 ) ) != fmt.Sprint ( right.Point ( 0.0 ) )	|| fmt.Sprint ( right.Point ( 1.0 ) ) != "[16 0]"	{
+		t.Errorf ( "Split curves expected to join at the middle, got : %v, %v", left.Control_points (), right.Control_points () )
+	}
+
+	if	derivative := curve.Derivative () ; fmt.Sprint ( derivative.Control_points () ) != "[[0 24] [48 0] [0 -24]]"	{
+		t.Errorf ( "Derivative expected = [[0 24] [48 0] [0 -24]], got : %v", derivative.Control_points () )
+	}
+
+//	Input and output are copies
+	var (
+		input		= [] VecN { { 1, 2, 3 } }
+		space, _	= New_bezier_curve ( input )
+	)
+	input [ 0 ][ 0 ]	= 100
+	space.Control_points () [ 0 ][ 1 ]	= 100
+
+	if	point, _ := space.Point3 ( 0.5 ) ; point != ( Point3 { 1, 2, 3 } )	{
+		t.Errorf ( "Curve expected to keep its points = [1 2 3], got : %v", point )
+	}
+
+	if	derivative := space.Derivative () ; fmt.Sprint ( derivative.Point ( 0.5 ) ) != "[0 0 0]"	{
+		t.Errorf ( "Derivative of a point expected = [0 0 0], got : %v", derivative.Point ( 0.5 ) )
+	}
+
+	for	_, points := range	[][] VecN { nil, { {} }, { { 1, 2 }, { 1 } } }	{
+
+		if	_, err := New_bezier_curve ( points ) ; err == nil	{
+			t.Errorf ( "Points %v are not valid but there is no error", points )
+		}
+	}
+
+	if	_, err := New_bezier_curve3 () ; err == nil	{
+		t.Error ( "There are no points but there is no error" )
+	}
+
+	if	Bezier_point ( nil, 0.5 ) != nil	{
+		t.Error ( "Bezier_point of a nil curve expected = nil" )
+	}
+}
+
+func Test_Akima_data ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var points	= make ( [] Point2, 10 )
+
+	for	i := range	points	{
+		points [ i ]	= Point2 { float64 ( i ) / 9.0, math.Sin ( float64 ( i ) / 9.0 ) }
+	}
+
+	var data, err	= New_akima_data ( points )
+
+	if	err != nil	{
+		t.Error ( err )
+		t.FailNow ()
+	}
+
+	if	data.Len () != 10	|| data.Point ( 3 ) != points [ 3 ]	{
+		t.Errorf ( "Data expected = %v, got : %v", points, data )
+	}
+
+	for	_, x := range	[] float64 { 0.0, 0.25, 0.5, 1.0 }	{
+
+		var y, err	= data.Value ( x )
+
+		if	err != nil	|| fmt.Sprintf ( "%.4f", y ) != fmt.Sprintf ( "%.4f", math.Sin ( x ) )	{
+			t.Errorf ( "Value ( %.4f ) expected = %.4f, got : %.4f, %v", x, math.Sin ( x ), y, err )
+		}
+	}
+
+	if	_, err := data.Value ( 2.0 ) ; err == nil	{
+		t.Error ( "x is out of bounds but there is no error" )
+	}
+
+	var cases	= [] * [][] float64 {
+		nil,
+		{ { 0, 0 }, { 1, 1 }, { 2, 2 }, { 3, 3 } },
+		{ { 0, 0 }, { 1, 1 }, { 2 }, { 3, 3 }, { 4, 4 } },
+		{ { 0, 0 }, { 1, 1 }, { 1, 2 }, { 3, 3 }, { 4, 4 } },
+		{ { 0, 0 }, { 2, 1 }, { 1, 2 }, { 3, 3 }, { 4, 4 } },
+	}
+
+	for	_, data_points := range	cases	{
+
+		if	_, err := Akima_data_from ( data_points ) ; err == nil	{
+			t.Errorf ( "Data %v is not valid but there is no error", data_points )
+		}
+
+		if	_, err := Akima_interval_curve ( data_points, 1.5 ) ; err == nil	{
+			t.Errorf ( "Data %v is not valid but Akima_interval_curve returned no error", data_points )
+		}
+	}
+}
@@ -0,0 +1,89 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"math/big"
+
+	"github.com/sjbog/math_tools/internal/pascal"
+)
+
+//	Rows are computed by the multiplicative recurrence and not cached, tables up to row 1000 are cached ( see internal/pascal ) and returned as copies, so callers can't modify the cache
+
+/*	Row n of Pascal's triangle : C ( n, 0 ) ... C ( n, n ), see Pascal_table
+
+	Return error ( Overflow_error ) if a coefficient doesn't fit uint64 ( n > 67 )
+*/
+func Pascal_row ( n  uint )		( [] uint64, error )	{
+
+	if	n > pascal.Uint64_max	{	return	nil, Overflow_error ()	}
+
+	return	pascal.Row ( n ), nil
+}
+
+//	Row n of Pascal's triangle as floats, coefficients are exact while they fit 2^53, correctly rounded up to row 67 and overflow to +Inf for n > 1029
+func Pascal_row_float ( n  uint )		[] float64	{
+
+	return	pascal.Row_float ( n )
+}
+
+//	Row n of Pascal's triangle, exact
+func Pascal_row_big ( n  uint )		[] * big.Int	{
+
+	return	pascal.Row_big ( n )
+}
+
+/*	Lower triangular table of rows 0 ... n of Pascal's triangle : table [ i ][ k ] = C ( i, k ), k <= i
+
+	Return error ( Overflow_error ) if a coefficient doesn't fit uint64 ( n > 67 )
+*/
+func Pascal_table ( n  uint )		( table  [][] uint64, err  error )	{
+
+	if	n > pascal.Uint64_max	{	return	nil, Overflow_error ()	}
+
+	return	pascal_copy ( pascal.Table ( n ) ), nil
+}
+
+//	Same as Pascal_table in float64, see Pascal_row_float
+func Pascal_table_float ( n  uint )		[][] float64	{
+
+	return	pascal_copy ( pascal.Table_float ( n ) )
+}
+
+//	Same as Pascal_table in big.Int
+func Pascal_table_big ( n  uint )		( table  [][] * big.Int )	{
+
+	table	= make ( [][] * big.Int, n +1 )
+
+	for	i, row := range	pascal.Table_big ( n )	{
+		table [ i ]	= pascal_copy_big ( row )
+	}
+	return
+}
+
+
+//	Rows of the table are copied into one backing array
+func pascal_copy [ T  any ] ( rows  [][] T )		( table  [][] T )	{
+
+	var values	= make ( [] T, 0, len ( rows ) * ( len ( rows ) +1 ) / 2 )
+
+	table	= make ( [][] T, len ( rows ) )
+
+	for	i, row := range	rows	{
+
+		values		= append ( values, row... )
+		table [ i ]	= values [ len ( values ) - len ( row ) : len ( values ) : len ( values ) ]
+	}
+	return
+}
+
+func pascal_copy_big ( row  [] * big.Int )		( result  [] * big.Int )	{
+
+	result	= make ( [] * big.Int, len ( row ) )
+
+	for	k, value := range	row	{
+		result [ k ]	= new ( big.Int ).Set ( value )
+	}
+	return
+}
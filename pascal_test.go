@@ -0,0 +1,138 @@
+//	Copyright (c) 2013, Bogdan S.
+//	Use of this source code is governed by a BSD-style license that can be found in the LICENSE file.
+
+package	math_tools
+
+import	(
+	"fmt"
+	"math"
+	"math/big"
+	"sync"
+	"testing"
+)
+
+
+func Test_Pascal_table ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		table, err	= Pascal_table ( 13 )
+		float_table	= Pascal_table_float ( 13 )
+		big_table	= Pascal_table_big ( 13 )
+	)
+
+	if	err != nil	{
+		t.Error ( err )
+		t.FailNow ()
+	}
+
+	for	n, row := range	Pascals_triangle	{
+
+		var expected	= fmt.Sprint ( row )
+
+		if	fmt.Sprint ( table [ n ] ) != expected	|| fmt.Sprint ( float_table [ n ] ) != expected	|| fmt.Sprint ( big_table [ n ] ) != expected	{
+			t.Errorf ( "Pascal's triangle row %d expected = %s, got : %v, %v, %v", n, expected, table [ n ], float_table [ n ], big_table [ n ] )
+		}
+	}
+
+	if	len ( table ) != 14	|| len ( float_table ) != 14	|| len ( big_table ) != 14	{
+		t.Errorf ( "Table of 13 rows expected = 14 rows, got : %d, %d, %d", len ( table ), len ( float_table ), len ( big_table ) )
+	}
+
+	var last, _	= Pascal_row ( 67 )
+
+	for	k, value := range	last	{
+
+		var expected, _	= Binomial_coefficient_checked ( 67, uint64 ( k ) )
+
+		if	value != expected	|| Pascal_row_float ( 67 ) [ k ] != float64 ( expected )	{
+			t.Errorf ( "Row 67 [ %d ] expected = %d, got : %d, %v", k, expected, value, Pascal_row_float ( 67 ) [ k ] )
+		}
+	}
+
+	if	_, err := Pascal_row ( 68 ) ; err == nil	{
+		t.Error ( "Row 68 overflows uint64 but there is no error" )
+	}
+
+	var row	= Pascal_row_big ( 200 )
+
+	for	k, value := range	row	{
+
+		if	value.Cmp ( Binomial_big ( 200, uint64 ( k ) ) ) != 0	{
+			t.Errorf ( "Big row 200 [ %d ] expected = %s, got : %s", k, Binomial_big ( 200, uint64 ( k ) ).String (), value.String () )
+		}
+	}
+
+//	Returned rows and tables are copies
+	row [ 1 ].SetInt64 ( 0 )
+	last [ 1 ]	= 0
+	table [ 13 ][ 1 ], float_table [ 13 ][ 1 ]	= 0, 0
+	big_table [ 13 ][ 1 ].SetInt64 ( 0 )
+	Pascal_table_big ( 200 ) [ 200 ][ 2 ].SetInt64 ( 0 )
+	Pascal_table_float ( 67 ) [ 67 ][ 2 ]	= 0
+
+	if	Pascal_table_big ( 200 ) [ 200 ][ 1 ].Int64 () != 200	|| Pascal_table_float ( 67 ) [ 67 ][ 1 ] != 67	||
+		Pascal_table_big ( 200 ) [ 200 ][ 2 ].Int64 () != 19900	|| Pascal_table_float ( 67 ) [ 67 ][ 2 ] != 2211	{
+
+		t.Error ( "Changing a returned row modified the cached table" )
+	}
+
+	if	table, _ := Pascal_table ( 13 ) ; table [ 13 ][ 1 ] != 13	|| Pascal_table_float ( 13 ) [ 13 ][ 1 ] != 13	|| Pascal_table_big ( 13 ) [ 13 ][ 1 ].Int64 () != 13	{
+		t.Error ( "Changing a returned table modified the cached table" )
+	}
+
+	if	len ( table [ 5 ] ) != 6	|| cap ( table [ 5 ] ) != 6	{
+		t.Errorf ( "Table row 5 expected length and capacity = 6, got : %d, %d", len ( table [ 5 ] ), cap ( table [ 5 ] ) )
+	}
+
+	if	result := Pascal_row_float ( 1100 ) [ 550 ] ; result <= 1e308	{
+		t.Errorf ( "C ( 1100, 550 ) overflows float64, expected = +Inf, got : %v", result )
+	}
+
+//	Large rows are computed by the recurrence, not by the cached table
+	for	_, c := range	[] struct { n, k  uint } { { 200, 1 }, { 200, 100 }, { 1029, 514 }, { 8000, 3 } }	{
+
+		var (
+			result, _	= new ( big.Float ).SetInt ( Binomial_big ( uint64 ( c.n ), uint64 ( c.k ) ) ).Float64 ()
+			row			= Pascal_row_float ( c.n )
+		)
+
+		if	len ( row ) != int ( c.n ) +1	|| math.Abs ( row [ c.k ] - result ) > 1e-12 * result	|| row [ c.k ] != row [ c.n - c.k ]	{
+			t.Errorf ( "Float row %d [ %d ] expected = %v, got : %v", c.n, c.k, result, row [ c.k ] )
+		}
+	}
+
+	if	row := Pascal_row_big ( 3000 ) ; row [ 1500 ].Cmp ( Binomial_big ( 3000, 1500 ) ) != 0	|| row [ 2999 ].Int64 () != 3000	{
+		t.Errorf ( "Big row 3000 expected = C ( 3000, 1500 ) and 3000, got : %s, %s", row [ 1500 ].String (), row [ 2999 ].String () )
+	}
+
+	if	table := Pascal_table_float ( 1010 ) ; len ( table ) != 1011	|| table [ 1010 ][ 1 ] != 1010	|| table [ 1000 ][ 2 ] != 499500	{
+		t.Error ( "Table above the cached rows expected = 1011 rows, got : ", len ( table ) )
+	}
+}
+
+func Test_Pascal_table_concurrent ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		group	sync.WaitGroup
+		expected	= new ( big.Int ).Binomial ( 300, 150 )
+	)
+
+	for	i := 0 ; i < 8 ; i ++	{
+
+		group.Add ( 1 )
+
+		go	func ( n  uint )	{
+
+			defer	group.Done ()
+
+			if	table := Pascal_table_big ( n ) ; table [ 300 ][ 150 ].Cmp ( expected ) != 0	{
+				t.Errorf ( "C ( 300, 150 ) expected = %s, got : %s", expected.String (), table [ 300 ][ 150 ].String () )
+			}
+		}( uint ( 300 + i * 10 ) )
+	}
+	group.Wait ()
+}
@@ -8,7 +8,9 @@
 package	math_tools
 
 import	(
+	"math"
 	"math/big"
+	"math/bits"
 	"strconv"
 )
 
@@ -30,14 +32,36 @@ func Arg_range_error ()		error	{
 
 	return	arg_range_error { "Error : passed argument(s) out of range" }
 }
+
+type  overflow_error  struct {
+
+	Msg	string
+}
+
+func ( self  overflow_error )	Error ()		string	{
+	return	self.Msg
+}
+
+/*	Returns an error "Error : result overflows the integer type"
+*/
+func Overflow_error ()		error	{
+
+	return	overflow_error { "Error : result overflows the integer type" }
+}
 //	---------------------------
 
 
+//	All signed and unsigned integer types
+type Integer interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+	~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr
+}
+
 /*	Property :
 		( x ^ y ) ^ y == x
 		( x ^ y ) ^ x == y
 */
-func Bit_swap ( x, y int )		( int, int )	{
+func Bit_swap [ T  Integer ] ( x, y  T )		( T, T )	{
 
 
 	x = x ^ y
@@ -48,30 +72,47 @@ func Bit_swap ( x, y int )		( int, int )	{
 }
 
 
-/*	Doesn't work : Ideal bool error
+/*	Branchless minimum
 
 		return y ^ ( ( x ^ y ) & -( x < y ) )
-*/
-func Bit_min ( x, y int )		int	{
 
-	if	x <= y	{	return x	}
+	where -( x < y ) is a mask of all ones if x < y and 0 otherwise, see bit_less_mask
 
-	return y
+	It is not an optimisation : the compiler turns x < y ? x : y and the builtin min into a conditional move,
+	which is about 2 times faster ( see Benchmark_Bit_min* ). Use it where the code must run in a constant time,
+	independent of the data ( the comparison is an arithmetic borrow, so nothing can depend on a branch )
+*/
+func Bit_min [ T  Integer ] ( x, y  T )		T	{
+
+	return	y ^ ( ( x ^ y ) & bit_less_mask ( x, y ) )
 }
 
 
-/*	Doesn't work : Ideal bool error
+/*	Branchless maximum
 
-		return y ^ ( (x ^ y) & -(x > y) )
-		or     x ^ ( (x ^ y) & -(x < y) )
-		or ( x >= y ) * x + ( x < y ) * y
+		return x ^ ( ( x ^ y ) & -( x < y ) )
+
+	About 2 times slower than the builtin max, for a constant time code as Bit_min
 */
-func Bit_max ( x, y int )		int {
+func Bit_max [ T  Integer ] ( x, y  T )		T {
 
+	return	x ^ ( ( x ^ y ) & bit_less_mask ( x, y ) )
+}
+
+/*	Mask of all ones if x < y, 0 otherwise ( Go has no bool to int conversion, so the comparison is an arithmetic borrow )
+
+	Values are extended to uint64, signed ones with the sign bit flipped ( so the unsigned order is the signed one ),
+	then the borrow of the subtraction ( math/bits.Sub64 is a single instruction ) is negated into the mask
+*/
+func bit_less_mask [ T  Integer ] ( x, y  T )		T	{
 
-	if	x >= y	{	return x	}
+	var (
+//		Sign bit for signed types : ^T ( 0 ) >> 1 is -1 for them and has no high bit for unsigned ones
+		flip	= uint64 ( ^T ( 0 ) >> 1 ) & ( 1 << 63 )
 
-	return y
+		_, borrow	= bits.Sub64 ( uint64 ( x ) ^ flip, uint64 ( y ) ^ flip, 0 )
+	)
+	return	T ( -borrow )
 }
 
 /*	Average of Integers
@@ -133,6 +174,8 @@ func BitAbs64( x int64 )	int64 {
 
 	= 2 * 3 * 4 * 5  /  ( 2  *  3 * 4 * 5 )
 	= 4 * 5  /  2
+
+	The result silently overflows uint for large n and k, see Binomial_coefficient_checked
 */
 func Binomial_coefficient ( n, k uint )		uint	{
 
@@ -172,8 +215,39 @@ func Binomial_coefficient ( n, k uint )		uint	{
 }
 
 
+/*	Same as Binomial_coefficient but returns an error ( see Overflow_error ) if the result doesn't fit uint64
+
+	Each step result * ( n - k + i ) / i is computed on 128 bits ( math/bits.Mul64 and Div64 ), the quotient is C ( n - k + i, i ) <= C ( n, k ),
+	so the overflow is detected exactly : err == nil if and only if C ( n, k ) < 2^64.
+
+	Unlike Binomial_coefficient returns 0 for k > n
+*/
+func Binomial_coefficient_checked ( n, k uint64 )		( result  uint64, err  error )	{
+
+	if	k > n	{	return 0, nil	}
+
+	if	k > n - k	{
+		k = n - k
+	}
+
+	result	= 1
+
+	for	i := uint64 ( 1 ) ; i <= k ; i ++	{
+
+		var high, low	= bits.Mul64 ( result, n - k + i )
+
+		if	high >= i	{	return	0, Overflow_error ()	}
+
+		result, _	= bits.Div64 ( high, low, i )
+	}
+	return
+}
+
+
 /*	Same as Binomial_coefficient but internally uses big.Int
 	from math/big package ( which doesn't overflow during int multiplication )
+
+	The result is truncated to uint64, see Binomial_big for exact large values
 */
 func Binomial_coeff_big ( n, k uint64 )		uint64	{
 
@@ -197,4 +271,379 @@ func Binomial_coeff_big ( n, k uint64 )		uint64	{
 	numerator.Quo ( numerator, denominator )
 
 	return	numerator.Uint64 ()
-}
\ No newline at end of file
+}
+
+/*	Exact "n choose k" as big.Int, see Binomial_big_to ( Binomial_coeff_big truncates the result to uint64 )
+*/
+func Binomial_big ( n, k uint64 )		* big.Int	{
+
+	return	Binomial_big_to ( new ( big.Int ), n, k )
+}
+
+/*	Writes exact "n choose k" into the result and returns it ( n < 2^63 ), the result is 0 for k > n
+
+	Usually the cancellation of MulRange products is used, as Binomial_coeff_big does.
+	For k >= binomial_legendre_threshold, that is a large fraction of a small n ( see binomial_legendre_suits ),
+	the result is factorised by Legendre's formula : the exponent of a prime p in C ( n, k ) is
+
+		Σ	[ n / p^i ]  -  [ k / p^i ]  -  [ ( n - k ) / p^i ],	i >= 1
+
+	so only primes <= n are multiplied ( balanced product ) and there is no long division
+*/
+func Binomial_big_to ( result  * big.Int, n, k  uint64 )		* big.Int	{
+
+	if	k > n	{	return	result.SetUint64 ( 0 )	}
+
+//	k	= Bit_min ( k, n - k )
+	if	k > n - k	{
+		k = n - k
+	}
+
+	if	binomial_legendre_suits ( n, k )	{
+		return	binomial_big_legendre ( result, n, k )
+	}
+
+	var denominator	big.Int
+
+	result.MulRange ( int64 ( n - k +1 ), int64 ( n ) )
+	denominator.MulRange ( 1, int64 ( k ) )
+
+	return	result.Quo ( result, & denominator )
+}
+
+const	(
+	binomial_legendre_threshold	= 256
+//	k >= n / binomial_legendre_fraction
+	binomial_legendre_fraction	= 8
+//	Sieve of the primes takes n bytes
+	binomial_legendre_max	= 1 << 24
+)
+
+/*	Legendre's factorisation sieves all the primes <= n, it pays off only when k is a large fraction of n ( k <= n / 2 ),
+	otherwise MulRange of k factors is much cheaper : C ( 1e8, 300 ) takes 0.1 ms by MulRange against seconds of the sieve
+*/
+func binomial_legendre_suits ( n, k  uint64 )		bool	{
+
+	return	k >= binomial_legendre_threshold	&& n <= binomial_legendre_max	&& k >= n / binomial_legendre_fraction
+}
+
+func binomial_big_legendre ( result  * big.Int, n, k  uint64 )		* big.Int	{
+
+	var (
+		composite	= make ( [] bool, n +1 )
+		factors		[] * big.Int
+
+//		Prime powers are packed into machine words before big.Int multiplication
+		word		= uint64 ( 1 )
+	)
+
+	for	p := uint64 ( 2 ) ; p <= n ; p ++	{
+
+		if	composite [ p ]	{	continue	}
+
+		for	multiple := p * p ; multiple <= n ; multiple += p	{
+			composite [ multiple ]	= true
+		}
+
+		var exponent	uint64
+
+		for	power := p ; ; power *= p	{
+
+			exponent	+= n / power - k / power - ( n - k ) / power
+
+			if	power > n / p	{	break	}
+		}
+
+		for	; exponent > 0 ; exponent --	{
+
+			if	high, low := bits.Mul64 ( word, p ) ; high == 0	{
+				word	= low
+			} else	{
+				factors	= append ( factors, new ( big.Int ).SetUint64 ( word ) )
+				word	= p
+			}
+		}
+	}
+	factors	= append ( factors, new ( big.Int ).SetUint64 ( word ) )
+
+//	Balanced product tree keeps the operands of similar sizes
+	for	len ( factors ) > 1	{
+
+		var next	= factors [ : 0 ]
+
+		for	i := 0 ; i < len ( factors ) ; i += 2	{
+
+			if	i +1 < len ( factors )	{
+				factors [ i ].Mul ( factors [ i ], factors [ i +1 ] )
+			}
+			next	= append ( next, factors [ i ] )
+		}
+		factors	= next
+	}
+	return	result.Set ( factors [ 0 ] )
+}
+
+/*	Binomial coefficient of real arguments	C ( n, k ) = Γ ( n +1 )  /  ( Γ ( k +1 ) * Γ ( n - k +1 ) )
+
+	For integer n and k the result is exact ( correctly rounded ) while C ( n, k ) fits uint64, see Binomial_coefficient_checked,
+	and 0 for integer k < 0 or 0 <= n < k. Negative integer n use the identity C ( n, k ) = ( -1 )^k * C ( k - n -1, k ).
+
+	Otherwise integer k <= binomial_product_max multiply the ratios ( n - k + i ) / i, other arguments use math.Lgamma.
+	Result overflows to ±Inf, it is ±Inf or NaN on the poles of Γ ( n +1 ) ( negative integer n, non integer k )
+*/
+func BinomialFloat ( n, k  float64 )		float64	{
+
+	if	result, ok := binomial_exact ( n, k ) ; ok	{	return	result	}
+
+	if	n, k, sign, ok := binomial_reflect ( n, k ) ; ok	{
+
+		var result	= sign
+
+		for	i := 1.0 ; i <= k ; i ++	{
+			result	= result * ( n - k + i ) / i
+		}
+		return	result
+	}
+
+	var log_abs, sign	= log_gamma_binomial ( n, k )
+
+	return	sign * math.Exp ( log_abs )
+}
+
+/*	Natural logarithm of | C ( n, k ) |, see BinomialFloat
+
+	Doesn't overflow for large n ( millions and more ), -Inf if the coefficient is 0
+*/
+func LogBinomial ( n, k  float64 )		float64	{
+
+	if	result, ok := binomial_exact ( n, k ) ; ok	{	return	math.Log ( math.Abs ( result ) )	}
+
+	if	n, k, _, ok := binomial_reflect ( n, k ) ; ok	{
+
+		var result	float64
+
+		for	i := 1.0 ; i <= k ; i ++	{
+			result	+= math.Log ( math.Abs ( n - k + i ) ) - math.Log ( i )
+		}
+		return	result
+	}
+
+	var log_abs, _	= log_gamma_binomial ( n, k )
+
+	return	log_abs
+}
+
+//	Integer k up to the limit multiply ( n - k + i ) / i instead of math.Lgamma, which loses precision by cancellation
+const	binomial_product_max	= 64
+
+//	Integer arguments with the coefficient ( or 0 ) representable by Binomial_coefficient_checked
+func binomial_exact ( n, k  float64 )		( result  float64, ok  bool )	{
+
+	if	k != math.Trunc ( k )	|| math.IsInf ( n, 0 )	|| math.IsInf ( k, 0 )	|| math.IsNaN ( n )	{
+		return	0, false
+	}
+
+	if	k < 0	{	return	0, true	}
+
+	if	n != math.Trunc ( n )	{	return	0, false	}
+
+	if	n >= 0 && k > n	{	return	0, true	}
+
+	var sign	= 1.0
+
+	if	n < 0	{
+		if	math.Mod ( k, 2 ) == 1	{	sign	= -1	}
+
+		n	= k - n -1
+	}
+
+	if	n >= 1 << 63	{	return	0, false	}
+
+	if	coefficient, err := Binomial_coefficient_checked ( uint64 ( n ), uint64 ( k ) ) ; err == nil	{
+		return	sign * float64 ( coefficient ), true
+	}
+	return	0, false
+}
+
+/*	Arguments of the ratios product : integer k <= binomial_product_max after the symmetry C ( n, k ) = C ( n, n - k ) of integer n
+	and the reflection of negative integer n
+*/
+func binomial_reflect ( n, k  float64 )		( n_result, k_result, sign  float64, ok  bool )	{
+
+	if	k != math.Trunc ( k )	|| math.IsInf ( n, 0 )	|| math.IsInf ( k, 0 )	{	return	}
+
+	sign	= 1.0
+
+	if	n == math.Trunc ( n )	{
+
+		if	n < 0	{
+			if	math.Mod ( k, 2 ) == 1	{	sign	= -1	}
+
+			n	= k - n -1
+		}
+
+		if	k > n - k	{	k	= n - k	}
+	}
+	return	n, k, sign, k <= binomial_product_max
+}
+
+func log_gamma_binomial ( n, k  float64 )		( log_abs, sign  float64 )	{
+
+	var (
+		numerator, sign_n	= math.Lgamma ( n +1 )
+		denominator1, sign_k	= math.Lgamma ( k +1 )
+		denominator2, sign_rest	= math.Lgamma ( n - k +1 )
+	)
+	return	numerator - denominator1 - denominator2, float64 ( sign_n * sign_k * sign_rest )
+}
+
+/*	Factorials and inverse factorials modulo a prime for binomial coefficients "n choose k" mod p
+
+	n < len ( tables ) :	C ( n, k ) = n ! * ( k ! )^-1 * ( ( n - k ) ! )^-1	mod p
+
+	Larger n use Lucas's theorem if the tables cover all residues ( size == p ) :
+
+		C ( n, k ) = Π C ( n_i, k_i )	mod p,	where n_i and k_i are digits of n and k in base p
+*/
+type Binomial_mod_table struct {
+
+	Prime	uint64
+	factorial, inverse	[] uint64
+}
+
+/*	Precomputes the tables for n < min ( size, prime ), inverses use Fermat's little theorem a^-1 = a^( p -2 ) mod p
+
+	Modulus must be a prime ( it is not checked ), return error if prime < 2 or size == 0
+*/
+func New_binomial_mod_table ( prime, size  uint64 )		( table  * Binomial_mod_table, err  error )	{
+
+	if	prime < 2	|| size == 0	{	return	table, Arg_range_error ()	}
+
+	if	size > prime	{	size	= prime	}
+
+	table	= & Binomial_mod_table {
+		Prime		: prime,
+		factorial	: make ( [] uint64, size ),
+		inverse		: make ( [] uint64, size ),
+	}
+	table.factorial [ 0 ]	= 1 % prime
+
+	for	i := uint64 ( 1 ) ; i < size ; i ++	{
+		table.factorial [ i ]	= mul_mod ( table.factorial [ i -1 ], i, prime )
+	}
+
+	table.inverse [ size -1 ]	= pow_mod ( table.factorial [ size -1 ], prime -2, prime )
+
+	for	i := size -1 ; i > 0 ; i --	{
+		table.inverse [ i -1 ]	= mul_mod ( table.inverse [ i ], i, prime )
+	}
+	return
+}
+
+/*	"n choose k" mod Prime, 0 for k > n
+
+	Return error if n is beyond the tables and they don't cover all residues for Lucas's theorem
+*/
+func ( self  * Binomial_mod_table )	Binomial ( n, k  uint64 )		( uint64, error )	{
+
+	if	k > n	{	return	0, nil	}
+
+	if	n < uint64 ( len ( self.factorial ) )	{	return	self.binomial ( n, k ), nil	}
+
+	if	uint64 ( len ( self.factorial ) ) < self.Prime	{	return	0, Arg_range_error ()	}
+
+	var result	= 1 % self.Prime
+
+	for	; n > 0	&& result != 0 ; n, k = n / self.Prime, k / self.Prime	{
+
+		var n_digit, k_digit	= n % self.Prime, k % self.Prime
+
+		if	k_digit > n_digit	{	return	0, nil	}
+
+		result	= mul_mod ( result, self.binomial ( n_digit, k_digit ), self.Prime )
+	}
+	return	result, nil
+}
+
+func ( self  * Binomial_mod_table )	binomial ( n, k  uint64 )		uint64	{
+
+	return	mul_mod ( mul_mod ( self.factorial [ n ], self.inverse [ k ], self.Prime ), self.inverse [ n - k ], self.Prime )
+}
+
+/*	"n choose k" mod prime without tables, see Binomial_mod_table
+
+	Lucas's theorem splits n and k into base prime digits, a digit coefficient takes O ( k_i ) multiplications,
+	so it suits small k or small primes. Return error if prime < 2
+*/
+func Binomial_mod ( n, k, prime  uint64 )		( uint64, error )	{
+
+	if	prime < 2	{	return	0, Arg_range_error ()	}
+
+	var result	= 1 % prime
+
+	for	; ( n > 0	|| k > 0 )	&& result != 0 ; n, k = n / prime, k / prime	{
+
+		var (
+			n_digit, k_digit	= n % prime, k % prime
+			numerator, denominator	= uint64 ( 1 ), uint64 ( 1 )
+		)
+
+		if	k_digit > n_digit	{	return	0, nil	}
+
+		if	k_digit > n_digit - k_digit	{	k_digit	= n_digit - k_digit	}
+
+		for	i := uint64 ( 1 ) ; i <= k_digit ; i ++	{
+			numerator	= mul_mod ( numerator, n_digit - k_digit + i, prime )
+			denominator	= mul_mod ( denominator, i, prime )
+		}
+
+		result	= mul_mod ( result, mul_mod ( numerator, pow_mod ( denominator, prime -2, prime ), prime ), prime )
+	}
+	return	result, nil
+}
+
+//	a * b mod m on 128 bits
+func mul_mod ( a, b, m  uint64 )		uint64	{
+
+	var high, low	= bits.Mul64 ( a, b )
+
+	return	bits.Rem64 ( high, low, m )
+}
+
+func pow_mod ( base, exponent, m  uint64 )		( result  uint64 )	{
+
+	result, base	= 1 % m, base % m
+
+	for	; exponent > 0 ; exponent >>= 1	{
+
+		if	exponent & 1 == 1	{	result	= mul_mod ( result, base, m )	}
+
+		base	= mul_mod ( base, base, m )
+	}
+	return
+}
+
+/*	Trinomial coefficient n ! / ( i ! * j ! * k ! ), where k = n - i - j
+
+	Multinomial extension of Binomial_coefficient for three groups :
+
+		( n ; i, j, k )	= C ( n, i ) * C ( n - i, j )
+
+	Returns 0 if i + j > n, error ( see Overflow_error ) if the result doesn't fit uint64
+*/
+func Trinomial_coefficient ( n, i, j uint64 )		( result  uint64, err  error )	{
+
+	if	i > n	|| j > n - i	{	return 0, nil	}
+
+	var first, second	uint64
+
+	if	first, err	= Binomial_coefficient_checked ( n, i ) ; err != nil	{	return	0, err	}
+
+	if	second, err	= Binomial_coefficient_checked ( n - i, j ) ; err != nil	{	return	0, err	}
+
+	var ok	bool
+
+	if	result, ok	= mul_checked ( first, second ) ; ! ok	{	return	0, Overflow_error ()	}
+
+	return
+}
@@ -4,6 +4,7 @@
 package	math_tools
 
 import	(
+	"math"
 	"math/big"
 	"testing"
 )
@@ -390,4 +391,444 @@ func Test_BitAbs ( t * testing.T )	{
 		t.Error( "Bit absolute int64 error" )
 		t.FailNow()
 	}
-}
\ No newline at end of file
+}
+
+func Test_Trinomial_coefficient ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var	factorial	= func ( n uint64 )	uint64	{
+
+		var result	= uint64 ( 1 )
+
+		for	; n > 1 ; n --	{	result	*= n	}
+
+		return	result
+	}
+
+	for	n := uint64 ( 0 ) ; n <= 12 ; n ++	{
+
+//		Sum of a trinomial row is 3 ^ n
+		var sum, power	= uint64 ( 0 ), uint64 ( 1 )
+
+		for	i := uint64 ( 0 ) ; i < n ; i ++	{	power	*= 3	}
+
+		for	i := uint64 ( 0 ) ; i <= n ; i ++	{
+			for	j := uint64 ( 0 ) ; i + j <= n ; j ++	{
+
+				var (
+					result, err	= Trinomial_coefficient ( n, i, j )
+					expected	= factorial ( n ) / ( factorial ( i ) * factorial ( j ) * factorial ( n - i - j ) )
+				)
+
+				if	err != nil	|| result != expected	{
+					t.Errorf ( "( %d ; %d, %d, %d ) expected = %d, got : %d ( %v )", n, i, j, n - i - j, expected, result, err )
+					t.FailNow ()
+				}
+				sum	+= result
+			}
+		}
+
+		if	sum != power	{
+			t.Errorf ( "Sum of trinomials of %d expected = %d, got : %d", n, power, sum )
+		}
+	}
+
+	if	result, err := Trinomial_coefficient ( 5, 3, 3 ) ; result != 0	|| err != nil	{
+		t.Error ( "Trinomial out of range should be 0" )
+	}
+
+	if	result, err := Trinomial_coefficient ( 5, 6, 0 ) ; result != 0	|| err != nil	{
+		t.Error ( "Trinomial out of range should be 0" )
+	}
+
+//	Each binomial fits uint64, their product doesn't
+	if	result, err := Trinomial_coefficient ( 60, 20, 20 ) ; err == nil	{
+		t.Errorf ( "( 60 ; 20, 20, 20 ) overflows uint64 but there is no error, got : %d", result )
+	}
+
+	if	result, err := Trinomial_coefficient ( 100, 50, 25 ) ; err == nil	{
+		t.Errorf ( "( 100 ; 50, 25, 25 ) overflows uint64 but there is no error, got : %d", result )
+	}
+
+	if	result, err := Trinomial_coefficient ( 40, 13, 13 ) ; err != nil	|| Multinomial_big ( 13, 13, 14 ).Uint64 () != result	{
+		t.Errorf ( "( 40 ; 13, 13, 14 ) expected = %s, got : %d ( %v )", Multinomial_big ( 13, 13, 14 ).String (), result, err )
+	}
+}
+
+func Test_Binomial_coefficient_checked ( t * testing.T )	{
+
+	t.Parallel ()
+
+	for	n, row := range	Pascals_triangle	{
+		for	k, expected := range	row	{
+
+			if	result, err := Binomial_coefficient_checked ( uint64 ( n ), uint64 ( k ) ) ; err != nil	|| result != uint64 ( expected )	{
+				t.Errorf ( "Binomial_coefficient_checked ( %d, %d ) expected = %d, got : %d, %v", n, k, expected, result, err )
+			}
+		}
+	}
+
+	var (
+		numerator	big.Int
+		limit	= new ( big.Int ).Lsh ( big.NewInt ( 1 ), 64 )
+	)
+
+//	Every coefficient of the rows around the uint64 limit is compared with big.Int
+	for	n := uint64 ( 60 ) ; n <= 70 ; n ++	{
+		for	k := uint64 ( 0 ) ; k <= n ; k ++	{
+
+			numerator.Binomial ( int64 ( n ), int64 ( k ) )
+
+			var result, err	= Binomial_coefficient_checked ( n, k )
+
+			if	numerator.Cmp ( limit ) >= 0	{
+
+				if	err == nil	{
+					t.Errorf ( "Binomial_coefficient_checked ( %d, %d ) = %s overflows uint64 but there is no error", n, k, numerator.String () )
+				}
+				continue
+			}
+
+			if	err != nil	|| result != numerator.Uint64 ()	{
+				t.Errorf ( "Binomial_coefficient_checked ( %d, %d ) expected = %s, got : %d, %v", n, k, numerator.String (), result, err )
+			}
+		}
+	}
+
+	for	_, c := range	[][ 3 ] uint64 { { 5, 6, 0 }, { 0, 0, 1 }, { 1 << 40, 1, 1 << 40 }, { 1 << 63, 1 << 63, 1 } }	{
+
+		if	result, err := Binomial_coefficient_checked ( c [ 0 ], c [ 1 ] ) ; err != nil	|| result != c [ 2 ]	{
+			t.Errorf ( "Binomial_coefficient_checked ( %d, %d ) expected = %d, got : %d, %v", c [ 0 ], c [ 1 ], c [ 2 ], result, err )
+		}
+	}
+
+	if	_, err := Binomial_coefficient_checked ( 1 << 40, 2 ) ; err == nil	{
+		t.Error ( "Binomial_coefficient_checked ( 2^40, 2 ) overflows uint64 but there is no error" )
+	}
+}
+
+func Test_Binomial_big ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		expected	big.Int
+		result		= new ( big.Int )
+	)
+
+	for	_, n := range	[] uint64 { 0, 1, 2, 13, 64, 100, 255, 256, 257, 600, 1000 }	{
+		for	_, k := range	[] uint64 { 0, 1, 2, 5, 50, 255, 256, 300, 500, 744, 1000 }	{
+
+			if	k > n	{
+
+				if	Binomial_big ( n, k ).Sign () != 0	{
+					t.Errorf ( "Binomial_big ( %d, %d ) expected = 0, got : %s", n, k, Binomial_big ( n, k ).String () )
+				}
+				continue
+			}
+
+			expected.Binomial ( int64 ( n ), int64 ( k ) )
+
+			if	Binomial_big_to ( result, n, k ) != result	|| result.Cmp ( & expected ) != 0	{
+				t.Errorf ( "Binomial_big ( %d, %d ) expected = %s, got : %s", n, k, expected.String (), result.String () )
+			}
+
+			if	binomial_big_legendre ( result, n, k ).Cmp ( & expected ) != 0	{
+				t.Errorf ( "binomial_big_legendre ( %d, %d ) expected = %s, got : %s", n, k, expected.String (), result.String () )
+			}
+		}
+	}
+
+	if	result := Binomial_big ( 100, 50 ).String () ; result != "100891344545564193334812497256"	{
+		t.Errorf ( "Binomial_big ( 100, 50 ) expected = 100891344545564193334812497256, got : %s", result )
+	}
+
+	if	result := Binomial_big ( 53, 13 ).String () ; result != "841392966470"	{
+		t.Errorf ( "Binomial_big ( 53, 13 ) expected = 841392966470, got : %s", result )
+	}
+
+//	Large n and small k must not sieve n bytes
+	for	_, c := range	[] struct { n, k  uint64 } { { 1e8, 300 }, { 1e9, 256 }, { 1 << 40, 1000 } }	{
+
+		if	binomial_legendre_suits ( c.n, c.k )	{
+			t.Errorf ( "binomial_legendre_suits ( %d, %d ) expected = false", c.n, c.k )
+		}
+
+		expected.Binomial ( int64 ( c.n ), int64 ( c.k ) )
+
+		if	Binomial_big_to ( result, c.n, c.k ).Cmp ( & expected ) != 0	{
+			t.Errorf ( "Binomial_big ( %d, %d ) expected = %s, got : %s", c.n, c.k, expected.String (), result.String () )
+		}
+	}
+
+	if	! binomial_legendre_suits ( 100000, 50000 )	{
+		t.Error ( "binomial_legendre_suits ( 100000, 50000 ) expected = true" )
+	}
+}
+
+//	MulRange cancellation, as Binomial_coeff_big does
+func Benchmark_Binomial_big_multiplicative ( b * testing.B )	{
+
+	var result	big.Int
+
+	for	i := 0 ; i < b.N ; i ++	{
+		result.Binomial ( 100000, 50000 )
+	}
+}
+
+func Benchmark_Binomial_big_legendre ( b * testing.B )	{
+
+	var result	big.Int
+
+	for	i := 0 ; i < b.N ; i ++	{
+		Binomial_big_to ( & result, 100000, 50000 )
+	}
+}
+
+func Test_BinomialFloat ( t * testing.T )	{
+
+	t.Parallel ()
+
+//	Exact wherever Binomial_coefficient doesn't overflow
+	for	n := uint ( 0 ) ; n <= 62 ; n ++	{
+		for	k := uint ( 0 ) ; k <= n ; k ++	{
+
+			var expected	= float64 ( Binomial_coefficient ( n, k ) )
+
+			if	result := BinomialFloat ( float64 ( n ), float64 ( k ) ) ; result != expected	{
+				t.Errorf ( "BinomialFloat ( %d, %d ) expected = %v, got : %v", n, k, expected, result )
+			}
+
+			if	result := LogBinomial ( float64 ( n ), float64 ( k ) ) ; math.Abs ( result - math.Log ( expected ) ) > 1e-12	{
+				t.Errorf ( "LogBinomial ( %d, %d ) expected = %v, got : %v", n, k, math.Log ( expected ), result )
+			}
+		}
+	}
+
+	var cases	= [] struct {
+		n, k, expected	float64
+	}{
+		{ 5, 7, 0 },
+		{ 5, -1, 0 },
+		{ 0.5, -2, 0 },
+		{ 0.5, 2, -0.125 },
+		{ 0.5, 3, 0.0625 },
+		{ -1, 5, -1 },
+		{ -1, 6, 1 },
+		{ -3, 2, 6 },
+		{ 2.5, 1.5, 2.5 },
+		{ 1e6, 1, 1e6 },
+		{ 1e6, 3, 1e6 * ( 1e6 -1 ) * ( 1e6 -2 ) / 6 },
+		{ 100, 50, 100891344545564193334812497256 },
+		{ 1000, 500, 2.7028824094543655e299 },
+	}
+
+	for	_, c := range	cases	{
+
+		if	result := BinomialFloat ( c.n, c.k ) ; math.Abs ( result - c.expected ) > 1e-12 * math.Max ( 1, math.Abs ( c.expected ) )	{
+			t.Errorf ( "BinomialFloat ( %v, %v ) expected = %v, got : %v", c.n, c.k, c.expected, result )
+		}
+	}
+
+	var bit_length	= Binomial_big ( 1000000, 500000 ).BitLen ()
+
+//	Binary logarithm of the exact value lies in [ bit_length -1, bit_length )
+	if	result := LogBinomial ( 1e6, 5e5 ) ; math.Abs ( result - 693140.047013063 ) > 1e-6	|| math.Abs ( result / math.Ln2 - float64 ( bit_length ) ) > 1	{
+		t.Errorf ( "LogBinomial ( 1e6, 5e5 ) expected = 693140.047013063, got : %v", result )
+	}
+
+	if	result := BinomialFloat ( 1e6, 5e5 ) ; ! math.IsInf ( result, 1 )	{
+		t.Errorf ( "BinomialFloat ( 1e6, 5e5 ) expected = +Inf, got : %v", result )
+	}
+}
+
+func Test_Binomial_mod ( t * testing.T )	{
+
+	t.Parallel ()
+
+	var (
+		expected, modulus	big.Int
+		primes	= [] uint64 { 2, 3, 7, 13, 101, 998244353, 1000000007, 1 << 61 -1 }
+	)
+
+	for	_, prime := range	primes	{
+
+		var (
+			size	= min ( prime, 64 )
+			table, err	= New_binomial_mod_table ( prime, size )
+		)
+
+		if	err != nil	{
+			t.Error ( err )
+			t.FailNow ()
+		}
+
+		modulus.SetUint64 ( prime )
+
+		for	n := uint64 ( 0 ) ; n <= 150 ; n ++	{
+			for	k := uint64 ( 0 ) ; k <= n +1 ; k ++	{
+
+				expected.Mod ( Binomial_big ( n, k ), & modulus )
+
+				if	result, err := Binomial_mod ( n, k, prime ) ; err != nil	|| result != expected.Uint64 ()	{
+					t.Errorf ( "Binomial_mod ( %d, %d, %d ) expected = %d, got : %d, %v", n, k, prime, expected.Uint64 (), result, err )
+					t.FailNow ()
+				}
+
+				var result, err	= table.Binomial ( n, k )
+
+//				Lucas's theorem needs the whole table
+				if	n >= size	&& size < prime	&& k <= n	{
+
+					if	err == nil	{	t.Errorf ( "Binomial ( %d, %d ) is beyond the table of %d but there is no error", n, k, prime )	}
+					continue
+				}
+
+				if	err != nil	|| result != expected.Uint64 ()	{
+					t.Errorf ( "Table binomial ( %d, %d ) mod %d expected = %d, got : %d, %v", n, k, prime, expected.Uint64 (), result, err )
+					t.FailNow ()
+				}
+			}
+		}
+	}
+
+//	Huge n : table with Lucas's theorem against digit products
+	var table, _	= New_binomial_mod_table ( 13, 13 )
+
+	for	_, c := range	[][ 2 ] uint64 { { 1e18, 1e9 }, { 1 << 63, 12345678 }, { 1e18 +7, 1e18 }, { 13 * 13 * 13, 13 } }	{
+
+		var (
+			result, _	= table.Binomial ( c [ 0 ], c [ 1 ] )
+			expected, _	= Binomial_mod ( c [ 0 ], c [ 1 ], 13 )
+		)
+
+		if	result != expected	{
+			t.Errorf ( "Binomial ( %d, %d ) mod 13 expected = %d, got : %d", c [ 0 ], c [ 1 ], expected, result )
+		}
+	}
+
+//	C ( 13^3, 13 ) = C ( 1, 0 ) * C ( 0, 1 ) * C ( 0, 0 ) * C ( 0, 0 ) = 0 mod 13 by Lucas's theorem
+	if	result, _ := Binomial_mod ( 13 * 13 * 13, 13, 13 ) ; result != 0	{
+		t.Errorf ( "Binomial_mod ( 2197, 13, 13 ) expected = 0, got : %d", result )
+	}
+
+	if	_, err := New_binomial_mod_table ( 1, 10 ) ; err == nil	{
+		t.Error ( "Modulus 1 is not a prime but there is no error" )
+	}
+
+	if	_, err := Binomial_mod ( 10, 2, 0 ) ; err == nil	{
+		t.Error ( "Modulus 0 is not a prime but there is no error" )
+	}
+}
+
+func Test_Bit_min_max_widths ( t * testing.T )	{
+
+	t.Parallel ()
+
+	for	x := math.MinInt8 ; x <= math.MaxInt8 ; x ++	{
+		for	y := math.MinInt8 ; y <= math.MaxInt8 ; y ++	{
+
+			var a, b	= int8 ( x ), int8 ( y )
+
+			if	Bit_min ( a, b ) != min ( a, b )	|| Bit_max ( a, b ) != max ( a, b )	{
+				t.Errorf ( "int8 min / max ( %d, %d ) expected = %d, %d, got : %d, %d", a, b, min ( a, b ), max ( a, b ), Bit_min ( a, b ), Bit_max ( a, b ) )
+			}
+
+			var c, d	= uint8 ( x ), uint8 ( y )
+
+			if	Bit_min ( c, d ) != min ( c, d )	|| Bit_max ( c, d ) != max ( c, d )	{
+				t.Errorf ( "uint8 min / max ( %d, %d ) expected = %d, %d, got : %d, %d", c, d, min ( c, d ), max ( c, d ), Bit_min ( c, d ), Bit_max ( c, d ) )
+			}
+
+			if	e, f := Bit_swap ( a, b ) ; e != b	|| f != a	{
+				t.Errorf ( "int8 swap ( %d, %d ) got : %d, %d", a, b, e, f )
+			}
+		}
+	}
+
+	var signed	= [] int64 { math.MinInt64, math.MinInt64 +1, -1, 0, 1, math.MaxInt64 -1, math.MaxInt64 }
+
+	for	_, x := range	signed	{
+		for	_, y := range	signed	{
+
+			if	Bit_min ( x, y ) != min ( x, y )	|| Bit_max ( x, y ) != max ( x, y )	{
+				t.Errorf ( "int64 min / max ( %d, %d ) got : %d, %d", x, y, Bit_min ( x, y ), Bit_max ( x, y ) )
+			}
+
+			if	a, b := int32 ( x >> 32 ), int32 ( y >> 32 ) ; Bit_min ( a, b ) != min ( a, b )	|| Bit_max ( a, b ) != max ( a, b )	{
+				t.Errorf ( "int32 min / max ( %d, %d ) got : %d, %d", a, b, Bit_min ( a, b ), Bit_max ( a, b ) )
+			}
+
+			if	a, b := uint64 ( x ), uint64 ( y ) ; Bit_min ( a, b ) != min ( a, b )	|| Bit_max ( a, b ) != max ( a, b )	{
+				t.Errorf ( "uint64 min / max ( %d, %d ) got : %d, %d", a, b, Bit_min ( a, b ), Bit_max ( a, b ) )
+			}
+
+			if	a, b := uintptr ( x ), uintptr ( y ) ; Bit_min ( a, b ) != min ( a, b )	|| Bit_max ( a, b ) != max ( a, b )	{
+				t.Errorf ( "uintptr min / max ( %d, %d ) got : %d, %d", a, b, Bit_min ( a, b ), Bit_max ( a, b ) )
+			}
+		}
+	}
+}
+
+//	Branchy versions for the benchmarks ( the compiler may turn them and built-in min / max into conditional moves )
+func branchy_min ( x, y  int )		int	{
+
+	if	x <= y	{	return x	}
+
+	return y
+}
+
+func branchy_max ( x, y  int )		int	{
+
+	if	x >= y	{	return x	}
+
+	return y
+}
+
+//	Pseudo random values defeat the branch prediction
+func benchmark_values ()		( values  [ 1024 ] int )	{
+
+	var state	= uint64 ( 88172645463325252 )
+
+	for	i := range	values	{
+
+		state	^= state << 13
+		state	^= state >> 7
+		state	^= state << 17
+		values [ i ]	= int ( state )
+	}
+	return
+}
+
+func Benchmark_Bit_min ( b * testing.B )	{
+
+	var values, sum	= benchmark_values (), 0
+
+	for	i := 0 ; i < b.N ; i ++	{
+		sum	+= Bit_min ( values [ i & 1023 ], values [ ( i +1 ) & 1023 ] ) + Bit_max ( values [ i & 1023 ], values [ ( i +7 ) & 1023 ] )
+	}
+	benchmark_sink	= sum
+}
+
+func Benchmark_Bit_min_branchy ( b * testing.B )	{
+
+	var values, sum	= benchmark_values (), 0
+
+	for	i := 0 ; i < b.N ; i ++	{
+		sum	+= branchy_min ( values [ i & 1023 ], values [ ( i +1 ) & 1023 ] ) + branchy_max ( values [ i & 1023 ], values [ ( i +7 ) & 1023 ] )
+	}
+	benchmark_sink	= sum
+}
+
+func Benchmark_Bit_min_builtin ( b * testing.B )	{
+
+	var values, sum	= benchmark_values (), 0
+
+	for	i := 0 ; i < b.N ; i ++	{
+		sum	+= min ( values [ i & 1023 ], values [ ( i +1 ) & 1023 ] ) + max ( values [ i & 1023 ], values [ ( i +7 ) & 1023 ] )
+	}
+	benchmark_sink	= sum
+}
+
+var benchmark_sink	int